@@ -0,0 +1,562 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/X-for/ltgo/internal/client"
+	"github.com/X-for/ltgo/internal/config"
+	"github.com/X-for/ltgo/internal/models"
+	"github.com/X-for/ltgo/internal/render"
+	"github.com/X-for/ltgo/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var browseCmd = &cobra.Command{
+	Use:   "browse",
+	Short: "Browse problems in a full-screen terminal UI",
+	Long: `Browse the problem catalog in a full-screen terminal UI.
+
+Keys:
+  ↑/↓ j/k      move (more problems load as you scroll)
+  /            search by keyword
+  d / s        cycle the difficulty / status filter
+  t            filter by tag (slug, e.g. dynamic-programming)
+  c            clear all filters
+  g            generate a solution file for the selected problem
+  enter / v    show the full description
+  o            open the problem in the browser
+  q / esc      quit`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runBrowse(cmd.Context())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(browseCmd)
+	addReadmeFlag(browseCmd)
+	addDescLangFlag(browseCmd)
+}
+
+// browsePageSize 滚动到底时每次多加载多少题
+const browsePageSize = 50
+
+var (
+	browseDifficulties = []string{"", "EASY", "MEDIUM", "HARD"}
+	browseStatuses     = []string{"", "TO_DO", "SOLVED", "ATTEMPTED"}
+)
+
+func runBrowse(ctx context.Context) {
+	cfg, err := config.Load()
+	if err != nil {
+		printConfigError(err)
+		return
+	}
+	lang, err := descLang(cfg)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if !tui.Interactive() {
+		fmt.Println("Error: 'ltgo browse' needs a terminal. Use 'ltgo list' in scripts.")
+		return
+	}
+
+	s, err := tui.OpenFullscreen()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	defer s.Close()
+
+	b := &browseView{ctx: ctx, c: client.New(cfg), cfg: cfg, s: s, lang: lang, stale: true,
+		details: map[string]*models.QuestionDetail{}, failed: map[string]error{}}
+	b.c.Refresh = refreshCache
+	b.run()
+}
+
+// browseView ltgo browse 的状态
+// 按键和网络请求都在一个循环里同步处理: 每次画完界面，没有积压的按键时才去加载列表或预览，
+// 连续按键 (打字、按住方向键) 时中间的请求自然就跳过了；
+// 请求期间终端回到普通模式 (见 fetch)，Ctrl-C 会取消 ctx，请求返回后就退出
+type browseView struct {
+	ctx  context.Context
+	c    *client.Client
+	cfg  *config.Config
+	s    *tui.Screen
+	lang string // 描述语言，见 render.Description
+
+	filter  client.SearchOptions // 关键词、难度、状态、标签
+	items   []models.Question
+	total   int
+	hasMore bool
+	stale   bool // 过滤条件变了，要从第一页重新加载
+	loadErr error
+
+	cursor, offset int
+	details        map[string]*models.QuestionDetail // slug -> 详情 (预览用)
+	failed         map[string]error                  // 加载预览失败的题，不再重试
+
+	input   *browseInput // 正在输入搜索词或标签
+	message string       // 底部的提示，按下一个键后清掉
+}
+
+// browseInput 底部的输入框
+type browseInput struct {
+	label string
+	text  []rune
+	old   string // 打开输入框前的值，Esc 时恢复
+	live  bool   // 每输入一个字就生效 (搜索词)；否则回车才生效 (标签)
+	apply func(string)
+}
+
+func (b *browseView) run() {
+	for {
+		if b.ctx.Err() != nil {
+			return
+		}
+		b.draw()
+		if !b.s.Pending() && b.load() {
+			continue
+		}
+		ev, err := b.s.ReadKey()
+		if err != nil {
+			return
+		}
+		b.message = ""
+		if b.input != nil {
+			b.edit(ev)
+			continue
+		}
+		if !b.handle(ev) {
+			return
+		}
+	}
+}
+
+// load 做一件需要联网的事: 重新加载列表、加载下一页或者加载预览；什么都没做时返回 false
+func (b *browseView) load() bool {
+	switch {
+	case b.stale:
+		b.stale = false
+		b.items, b.cursor, b.offset = nil, 0, 0
+		b.fetchPage()
+		return true
+	case b.hasMore && b.loadErr == nil && b.cursor >= len(b.items)-b.rows():
+		b.fetchPage()
+		return true
+	}
+
+	if q, ok := b.selected(); ok && b.previewWidth() > 0 {
+		if _, ok := b.details[q.TitleSlug]; !ok && b.failed[q.TitleSlug] == nil {
+			b.fetch(func() {
+				if d, err := b.c.GetQuestionDetail(b.ctx, q.TitleSlug); err != nil {
+					b.failed[q.TitleSlug] = err
+				} else {
+					b.details[q.TitleSlug] = d
+				}
+			})
+			return true
+		}
+	}
+	return false
+}
+
+func (b *browseView) fetchPage() {
+	opts := b.filter
+	opts.Skip = len(b.items)
+	opts.Limit = browsePageSize
+	var page *client.QuestionPage
+	var err error
+	b.fetch(func() { page, err = b.c.QueryQuestions(b.ctx, opts) })
+	if err != nil {
+		b.loadErr = err
+		return
+	}
+	b.loadErr = nil
+	b.items = append(b.items, page.Questions...)
+	b.total = page.Total
+	b.hasMore = page.HasMore && len(page.Questions) > 0
+}
+
+func (b *browseView) selected() (models.Question, bool) {
+	if b.cursor < len(b.items) {
+		return b.items[b.cursor], true
+	}
+	return models.Question{}, false
+}
+
+// handle 处理一个按键，返回 false 表示退出
+func (b *browseView) handle(ev tui.Event) bool {
+	switch ev.Key {
+	case tui.KeyEsc, tui.KeyCtrlC:
+		return false
+	case tui.KeyUp:
+		b.move(-1)
+	case tui.KeyDown:
+		b.move(1)
+	case tui.KeyPageUp:
+		b.move(-b.rows())
+	case tui.KeyPageDown:
+		b.move(b.rows())
+	case tui.KeyHome:
+		b.move(-len(b.items))
+	case tui.KeyEnd:
+		b.move(len(b.items))
+	case tui.KeyEnter:
+		b.show()
+	case tui.KeyRune:
+		return b.command(ev.Rune)
+	}
+	return true
+}
+
+func (b *browseView) command(r rune) bool {
+	switch r {
+	case 'q':
+		return false
+	case 'k':
+		b.move(-1)
+	case 'j':
+		b.move(1)
+	case '/':
+		b.input = &browseInput{label: "Search", text: []rune(b.filter.Keyword), old: b.filter.Keyword, live: true, apply: func(v string) {
+			b.setFilter(func(f *client.SearchOptions) { f.Keyword = v })
+		}}
+	case 't':
+		b.input = &browseInput{label: "Tag", text: []rune(b.filter.Tag), apply: func(v string) {
+			v = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(v)), " ", "-")
+			b.setFilter(func(f *client.SearchOptions) { f.Tag = v })
+		}}
+	case 'd':
+		b.setFilter(func(f *client.SearchOptions) { f.Difficulty = cycle(browseDifficulties, f.Difficulty) })
+	case 's':
+		b.setFilter(func(f *client.SearchOptions) { f.Status = cycle(browseStatuses, f.Status) })
+	case 'c':
+		b.setFilter(func(f *client.SearchOptions) { *f = client.SearchOptions{} })
+	case 'v':
+		b.show()
+	case 'g':
+		b.generate()
+	case 'o':
+		b.open()
+	}
+	return true
+}
+
+// edit 输入框里的按键: 回车确认，Esc 放弃 (实时生效的搜索词恢复原样)
+func (b *browseView) edit(ev tui.Event) {
+	in := b.input
+	switch ev.Key {
+	case tui.KeyEnter:
+		b.input = nil
+		in.apply(string(in.text))
+		return
+	case tui.KeyEsc, tui.KeyCtrlC:
+		b.input = nil
+		if in.live {
+			in.apply(in.old)
+		}
+		return
+	case tui.KeyBackspace:
+		if len(in.text) > 0 {
+			in.text = in.text[:len(in.text)-1]
+		}
+	case tui.KeyRune:
+		in.text = append(in.text, ev.Rune)
+	default:
+		return
+	}
+	if in.live {
+		in.apply(string(in.text))
+	}
+}
+
+func (b *browseView) setFilter(change func(f *client.SearchOptions)) {
+	old := b.filter
+	change(&b.filter)
+	if b.filter != old {
+		b.stale = true
+		b.loadErr = nil
+	}
+}
+
+// cycle 过滤条件按顺序切换到下一个值
+func cycle(values []string, cur string) string {
+	for i, v := range values {
+		if v == cur {
+			return values[(i+1)%len(values)]
+		}
+	}
+	return values[0]
+}
+
+func (b *browseView) move(delta int) {
+	if len(b.items) == 0 {
+		return
+	}
+	b.cursor = min(max(b.cursor+delta, 0), len(b.items)-1)
+}
+
+// detail 选中题目的详情，预览时加载过就不再请求
+func (b *browseView) detail() (*models.QuestionDetail, error) {
+	q, ok := b.selected()
+	if !ok {
+		return nil, fmt.Errorf("no problem selected")
+	}
+	if d, ok := b.details[q.TitleSlug]; ok {
+		return d, nil
+	}
+	var d *models.QuestionDetail
+	var err error
+	b.fetch(func() { d, err = b.c.GetQuestionDetail(b.ctx, q.TitleSlug) })
+	if err != nil {
+		return nil, err
+	}
+	b.details[q.TitleSlug] = d
+	return d, nil
+}
+
+// fetch 执行一次联网请求，期间终端回到普通模式，Ctrl-C 照常发出 SIGINT 取消 b.ctx
+// (raw 模式下 Ctrl-C 只是个按键，要等请求超时才能读到)
+func (b *browseView) fetch(f func()) {
+	if err := b.s.Interruptible(f); err != nil {
+		b.message = "❌ " + err.Error()
+	}
+}
+
+// show 离开全屏，用 ltgo show 的样式 (经过 $PAGER) 显示完整题面
+func (b *browseView) show() {
+	d, err := b.detail()
+	if err != nil {
+		b.message = "❌ " + err.Error()
+		return
+	}
+	b.s.Suspend()
+	opts := render.Options{Color: colorEnabled()}
+	page(showHeader(b.c, d, b.lang, opts) + "\n\n" + render.Terminal(render.Description(d, b.lang), opts) + "\n")
+	b.s.Wait("\nPress Enter to return to the list...")
+	b.resume()
+}
+
+// generate 离开全屏生成题解文件，输出和 ltgo gen 一样
+func (b *browseView) generate() {
+	d, err := b.detail()
+	if err != nil {
+		b.message = "❌ " + err.Error()
+		return
+	}
+	b.s.Suspend()
+	if err := generate(b.ctx, b.c, b.cfg, d); err != nil {
+		fmt.Printf("Failed to generate: %v\n", err)
+	}
+	b.s.Wait("\nPress Enter to return to the list...")
+	b.resume()
+}
+
+func (b *browseView) resume() {
+	if err := b.s.Resume(); err != nil {
+		b.message = "❌ " + err.Error()
+	}
+}
+
+func (b *browseView) open() {
+	q, ok := b.selected()
+	if !ok {
+		return
+	}
+	url := fmt.Sprintf("%s/problems/%s/", b.c.BaseURL, q.TitleSlug)
+	if err := openURL(url); err != nil {
+		b.message = "❌ Failed to open the browser: " + err.Error()
+		return
+	}
+	b.message = "Opened " + url
+}
+
+// openURL 用系统默认浏览器打开链接
+func openURL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// rows 列表区域能放几行: 去掉标题、分隔线、提示行和快捷键说明
+func (b *browseView) rows() int {
+	_, h := b.s.Size()
+	return max(h-4, 1)
+}
+
+// listWidth / previewWidth 终端够宽时右边显示题目预览
+func (b *browseView) listWidth() int {
+	w, _ := b.s.Size()
+	if b.previewWidth() == 0 {
+		return w - 1
+	}
+	return w * 45 / 100
+}
+
+func (b *browseView) previewWidth() int {
+	w, _ := b.s.Size()
+	if w < 100 {
+		return 0
+	}
+	return w - w*45/100 - 4
+}
+
+func (b *browseView) draw() {
+	opts := render.Options{Color: colorEnabled()}
+	rows := b.rows()
+	if b.cursor < b.offset {
+		b.offset = b.cursor
+	} else if b.cursor >= b.offset+rows {
+		b.offset = b.cursor - rows + 1
+	}
+
+	lines := []string{b.header(opts), opts.Dim(strings.Repeat("─", b.listWidth()+b.previewWidth()+3))}
+
+	list := b.listLines(opts, rows)
+	preview := b.previewLines(opts, rows)
+	for i := 0; i < rows; i++ {
+		line := tui.Pad(list[i], b.listWidth())
+		if b.previewWidth() > 0 {
+			p := ""
+			if i < len(preview) {
+				p = preview[i]
+			}
+			line += " " + opts.Dim("│") + " " + p
+		}
+		lines = append(lines, line)
+	}
+
+	switch {
+	case b.input != nil:
+		lines = append(lines, fmt.Sprintf("%s: %s▏", b.input.label, string(b.input.text)))
+	case b.message != "":
+		lines = append(lines, b.message)
+	case b.loadErr != nil:
+		lines = append(lines, "❌ "+b.loadErr.Error())
+	default:
+		lines = append(lines, "")
+	}
+	lines = append(lines, opts.Dim("↑↓ move  / search  d difficulty  s status  t tag  c clear  g gen  enter show  o open  q quit"))
+	b.s.Draw(lines)
+}
+
+func (b *browseView) header(opts render.Options) string {
+	label := func(v string) string {
+		if v == "" {
+			return "All"
+		}
+		return capitalize(strings.ReplaceAll(v, "_", " "))
+	}
+	h := opts.Bold("ltgo browse") + " · " + b.cfg.Site +
+		"   Difficulty: " + label(b.filter.Difficulty) +
+		"   Status: " + label(b.filter.Status)
+	if b.filter.Tag != "" {
+		h += "   Tag: " + b.filter.Tag
+	}
+	if b.filter.Keyword != "" {
+		h += fmt.Sprintf("   Search: %q", b.filter.Keyword)
+	}
+	switch {
+	case b.stale:
+		h += "   " + opts.Dim("loading...")
+	case b.total > 0:
+		h += "   " + opts.Dim(fmt.Sprintf("%d/%d", min(b.cursor+1, len(b.items)), b.total))
+	}
+	return h
+}
+
+func (b *browseView) listLines(opts render.Options, rows int) []string {
+	lines := make([]string, rows)
+	if len(b.items) == 0 {
+		if !b.stale && b.loadErr == nil {
+			lines[0] = opts.Dim("  No problems match the filters.")
+		}
+		return lines
+	}
+	for i := 0; i < rows && b.offset+i < len(b.items); i++ {
+		idx := b.offset + i
+		q := b.items[idx]
+		mark := " "
+		switch strings.ToUpper(q.Status) {
+		case "AC", "SOLVED":
+			mark = "✓"
+		case "TRIED", "ATTEMPTED", "NOTAC":
+			mark = "?"
+		}
+		title := questionTitle(q, b.lang)
+		if q.PaidOnly || q.IsPaidOnly {
+			title += " 🔒"
+		}
+		// 选中的行整行反色，里面就不再上色了
+		style := opts
+		if idx == b.cursor {
+			style = render.Options{}
+		}
+		diff := capitalize(q.Difficulty)
+		diff = style.Difficulty(diff) + strings.Repeat(" ", max(6-len(diff), 0))
+		row := fmt.Sprintf("%s %5s %s %s", mark, q.QuestionFrontendID, tui.Pad(title, b.listWidth()-15), diff)
+		if idx == b.cursor {
+			row = "\x1b[7m" + tui.Pad(row, b.listWidth()) + "\x1b[0m"
+		}
+		lines[i] = row
+	}
+	if b.offset+rows >= len(b.items) && b.hasMore {
+		if n := len(b.items) - b.offset; n < rows {
+			lines[n] = opts.Dim("  loading more...")
+		}
+	}
+	return lines
+}
+
+// previewLines 右边的预览: 标题、难度、状态和题面开头
+func (b *browseView) previewLines(opts render.Options, rows int) []string {
+	width := b.previewWidth()
+	q, ok := b.selected()
+	if width == 0 || !ok {
+		return nil
+	}
+	lines := []string{
+		opts.Bold(q.QuestionFrontendID + ". " + questionTitle(q, b.lang)),
+		opts.Difficulty(capitalize(q.Difficulty)) + " · " + statusText(q.Status),
+		"",
+	}
+	d, ok := b.details[q.TitleSlug]
+	switch {
+	case ok:
+		text := render.Terminal(render.Description(d, b.lang), render.Options{})
+		lines = append(lines, strings.Split(render.Wrap(text, width), "\n")...)
+	case b.failed[q.TitleSlug] != nil:
+		lines = append(lines, "❌ "+b.failed[q.TitleSlug].Error())
+	default:
+		lines = append(lines, opts.Dim("loading..."))
+	}
+	if len(lines) > rows {
+		lines = lines[:rows]
+	}
+	for i, line := range lines {
+		lines[i] = tui.Truncate(line, width)
+	}
+	return lines
+}
+
+// questionTitle 列表里的标题按描述语言选，见 render.Title
+func questionTitle(q models.Question, lang string) string {
+	return render.Title(&models.QuestionDetail{Title: q.Title, TranslatedTitle: q.TranslatedTitle}, lang)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/X-for/ltgo/internal/cache"
+	"github.com/X-for/ltgo/internal/client"
+	"github.com/X-for/ltgo/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// refreshCache 对应 gen / daily / run / submit 的 --refresh
+var refreshCache bool
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect the question detail cache",
+	Long: `Question details are cached on disk so that run and submit don't fetch
+them again every time. If run without arguments, it lists the cached questions.
+
+Use --refresh on gen, daily, run or submit to bypass the cache once.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		listCache()
+	},
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear [slug...]",
+	Short: "Remove cached questions (all of them if no slug is given)",
+	Run: func(cmd *cobra.Command, args []string) {
+		clearCache(args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
+
+// addRefreshFlag 给需要题目详情的命令加上 --refresh
+func addRefreshFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&refreshCache, "refresh", false, "Ignore the cached question details and fetch them again")
+}
+
+func openCache() *cache.Store {
+	cfg, err := config.Load()
+	if err != nil {
+		printConfigError(err)
+		return nil
+	}
+	store := client.New(cfg).Cache()
+	if store == nil {
+		fmt.Println("Cache is not available.")
+	}
+	return store
+}
+
+func listCache() {
+	store := openCache()
+	if store == nil {
+		return
+	}
+
+	items, err := store.List()
+	if err != nil {
+		fmt.Printf("Failed to read cache: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Cache directory: %s (TTL %s)\n", store.Dir(), store.TTL)
+	if len(items) == 0 {
+		fmt.Println("Cache is empty.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Slug\tTitle\tCached At\tSize")
+	fmt.Fprintln(w, "----\t-----\t---------\t----")
+
+	var total int64
+	for _, it := range items {
+		cachedAt := it.CachedAt.Format("2006-01-02 15:04")
+		if it.CachedAt.IsZero() {
+			cachedAt = "-"
+		}
+		if it.Expired {
+			cachedAt += " (expired)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%.1f KB\n", it.Slug, it.Title, cachedAt, float64(it.Size)/1024)
+		total += it.Size
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d questions, %.1f KB\n", len(items), float64(total)/1024)
+}
+
+func clearCache(slugs []string) {
+	store := openCache()
+	if store == nil {
+		return
+	}
+
+	if len(slugs) == 0 {
+		n, err := store.Clear()
+		if err != nil {
+			fmt.Printf("Failed to clear cache: %v\n", err)
+			return
+		}
+		fmt.Printf("✅ Removed %d cached questions\n", n)
+		return
+	}
+
+	for _, slug := range slugs {
+		if err := store.Remove(slug); err != nil {
+			fmt.Printf("Failed to remove '%s': %v\n", slug, err)
+			continue
+		}
+		fmt.Printf("✅ Removed '%s'\n", slug)
+	}
+}
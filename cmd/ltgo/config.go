@@ -2,9 +2,15 @@ package main
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/X-for/ltgo/internal/cache"
+	"github.com/X-for/ltgo/internal/client"
 	"github.com/X-for/ltgo/internal/config"
+	"github.com/X-for/ltgo/internal/generator"
+	"github.com/X-for/ltgo/internal/render"
 	"github.com/spf13/cobra"
 )
 
@@ -15,7 +21,12 @@ var configCmd = &cobra.Command{
 	Long: `Manage ltgo configuration.
 If run without arguments, it displays the current configuration.
 
-Available keys: language, site, cookie`,
+Available keys: language, site, cookie, cache_ttl, retries, wrap,
+description_language
+
+'ltgo config set' changes the active profile. Output directory, file layout
+and template are set per repository in a .ltgo.json workspace file, which also
+overrides the profile's language.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// 默认行为：显示配置
 		showConfig()
@@ -28,43 +39,156 @@ var configSetCmd = &cobra.Command{
 	Use:   "set [key] [value]",
 	Short: "Set a configuration value",
 	Example: `  ltgo config set language python3
-  ltgo config set site com`,
+  ltgo config set site com
+  ltgo config set cache_ttl 72h
+  ltgo config set retries 5
+  ltgo config set wrap 100
+  ltgo config set description_language both`,
 	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		setConfig(args[0], args[1])
 	},
 }
 
+var configEncryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Encrypt the stored cookies with a passphrase",
+	Long: `Encrypt the cookies in ~/.ltgo/credentials.json with a passphrase.
+Run it again to change the passphrase. See 'ltgo unlock'.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		encryptCredentials()
+	},
+}
+
+var configDecryptCmd = &cobra.Command{
+	Use:   "decrypt",
+	Short: "Remove the passphrase and store the cookies unencrypted",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := config.SetPassphrase(""); err != nil {
+			printConfigError(err)
+			return
+		}
+		fmt.Println("✅ Cookies are no longer encrypted (the file is still only readable by you).")
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
-	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configSetCmd, configEncryptCmd, configDecryptCmd)
 }
 
 func showConfig() {
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Printf("Error loading config: %v\n", err)
+		printConfigError(err)
 		return
 	}
 	fmt.Println("Current Configuration:")
-	fmt.Printf("  Language: %s\n", cfg.Language)
-	fmt.Printf("  Site:     %s\n", cfg.Site)
+	fmt.Printf("  Profile:   %s\n", cfg.Profile)
+	workspace := "None"
+	if cfg.Workspace != nil {
+		workspace = cfg.Workspace.Path
+	}
+	fmt.Printf("  Workspace: %s\n", workspace)
+	fmt.Printf("  Language:  %s%s\n", cfg.Language, sourceNote(cfg, "language"))
+	fmt.Printf("  Site:      %s%s\n", cfg.Site, sourceNote(cfg, "site"))
 
 	cookiePreview := "Not set"
 	if len(cfg.Cookie) > 20 {
 		cookiePreview = cfg.Cookie[:20] + "..."
 	}
-	fmt.Printf("  Cookie:   %s\n", cookiePreview)
+	fmt.Printf("  Cookie:    %s%s\n", cookiePreview, sourceNote(cfg, "cookie"))
+
+	cacheTTL := cfg.CacheTTL + sourceNote(cfg, "cache_ttl")
+	if cfg.CacheTTL == "" {
+		cacheTTL = cache.DefaultTTL.String() + " (default)"
+	}
+	fmt.Printf("  CacheTTL:  %s\n", cacheTTL)
+
+	retries := fmt.Sprintf("%d (default)", client.DefaultRetries)
+	if cfg.Retries != nil {
+		retries = strconv.Itoa(*cfg.Retries) + sourceNote(cfg, "retries")
+	}
+	fmt.Printf("  Retries:   %s\n", retries)
+
+	wrap := fmt.Sprintf("%d (default)", generator.DefaultWrap)
+	if cfg.Wrap != nil {
+		wrap = strconv.Itoa(*cfg.Wrap)
+		if *cfg.Wrap == 0 {
+			wrap = "off"
+		}
+	}
+	fmt.Printf("  Wrap:      %s\n", wrap)
+
+	descLang := cfg.DescriptionLanguage
+	if descLang == "" {
+		descLang = "auto (default)"
+	}
+	fmt.Printf("  DescLang:  %s\n", descLang)
+
+	// 下面几项只能在工作区的 .ltgo.json 里设置
+	fmt.Printf("  OutputDir: %s%s\n", cfg.OutputDir(), sourceNote(cfg, "output_dir"))
+	layout := cfg.Layout()
+	if layout == "" {
+		layout = generator.DefaultLayout
+	}
+	fmt.Printf("  Layout:    %s%s\n", layout, sourceNote(cfg, "layout"))
+	template := cfg.Template()
+	if template == "" {
+		template = "built-in"
+	}
+	fmt.Printf("  Template:  %s%s\n", template, sourceNote(cfg, "template"))
+}
+
+func encryptCredentials() {
+	// 已经加密时先用旧口令解锁 (SetPassphrase 里会问)
+	pass, err := readPassphrase("New passphrase: ")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	again, err := readPassphrase("Repeat passphrase: ")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if pass != again {
+		fmt.Println("Error: passphrases do not match")
+		return
+	}
+
+	if err := config.SetPassphrase(pass); err != nil {
+		printConfigError(err)
+		return
+	}
+	fmt.Println("🔐 Cookies encrypted. Commands will ask for the passphrase; run 'ltgo unlock' to enter it once per session.")
+}
+
+// sourceNote 标出值的来源 (flag / env / workspace / profile / default)
+func sourceNote(cfg *config.Config, key string) string {
+	return " (" + cfg.Source(key) + ")"
 }
 
 func setConfig(key, value string) {
 	key = strings.ToLower(key)
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Printf("Error loading config: %v\n", err)
+		printConfigError(err)
 		return
 	}
 
+	// 改的是 profile 里的值，当前目录下它可能仍被工作区、环境变量或命令行参数覆盖
+	name := key
+	if name == "lang" {
+		name = "language"
+	}
+	overridden := cfg.Source(name)
+	if strings.HasPrefix(overridden, "profile") || overridden == "default" {
+		overridden = ""
+	}
+
 	switch key {
 	case "language", "lang":
 		cfg.Language = value
@@ -76,6 +200,32 @@ func setConfig(key, value string) {
 		cfg.Site = value
 	case "cookie":
 		cfg.Cookie = value
+	case "cache_ttl", "cache-ttl":
+		if _, err := time.ParseDuration(value); err != nil {
+			fmt.Println("Error: cache_ttl must be a duration like '72h' or '30m'")
+			return
+		}
+		cfg.CacheTTL = value
+	case "retries":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			fmt.Println("Error: retries must be a non-negative number")
+			return
+		}
+		cfg.Retries = &n
+	case "wrap":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			fmt.Println("Error: wrap must be a non-negative number (0 disables wrapping)")
+			return
+		}
+		cfg.Wrap = &n
+	case "description_language", "description-language":
+		if err := render.CheckDescLang(value); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		cfg.DescriptionLanguage = value
 	default:
 		fmt.Printf("Error: unknown configuration key '%s'\n", key)
 		return
@@ -87,4 +237,7 @@ func setConfig(key, value string) {
 	}
 
 	fmt.Printf("✅ Updated %s to '%s'\n", key, value)
+	if overridden != "" {
+		fmt.Printf("Note: '%s' is overridden here by %s\n", name, overridden)
+	}
 }
@@ -1,12 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"os"
 
 	"github.com/X-for/ltgo/internal/client"
 	"github.com/X-for/ltgo/internal/config"
-	"github.com/X-for/ltgo/internal/generator"
 	"github.com/spf13/cobra"
 )
 
@@ -15,26 +14,30 @@ var dailyCmd = &cobra.Command{
 	Short: "Get today's daily question",
 	Long:  `Fetch and generate the daily question from LeetCode.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		runDaily()
+		runDaily(cmd.Context())
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(dailyCmd)
+	addRefreshFlag(dailyCmd)
+	addReadmeFlag(dailyCmd)
+	addDescLangFlag(dailyCmd)
 }
 
-func runDaily() {
+func runDaily(ctx context.Context) {
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Println("Please run 'ltgo init' first.")
+		printConfigError(err)
 		return
 	}
 	c := client.New(cfg)
+	c.Refresh = refreshCache
 
 	fmt.Println("Fetching daily question...")
-	q, err := c.GetDailyQuestion()
+	q, err := c.GetDailyQuestion(ctx)
 	if err != nil {
-		fmt.Printf("Failed to get daily question: %v\n", err)
+		printError("Failed to get daily question", err)
 		return
 	}
 
@@ -42,16 +45,13 @@ func runDaily() {
 
 	// 复用生成逻辑
 	fmt.Printf("Fetching details for '%s'...\n", q.TitleSlug)
-	detail, err := c.GetQuestionDetail(q.TitleSlug)
+	detail, err := c.GetQuestionDetail(ctx, q.TitleSlug)
 	if err != nil {
-		fmt.Printf("Failed to get details: %v\n", err)
+		printError("Failed to get details", err)
 		return
 	}
 
-	cwd, _ := os.Getwd()
-	outputDir := fmt.Sprintf("%s/questions", cwd)
-
-	if err := generator.Generate(detail, outputDir, cfg.Site, cfg.Language); err != nil {
+	if err := generate(ctx, c, cfg, detail); err != nil {
 		fmt.Printf("Failed to generate: %v\n", err)
 		return
 	}
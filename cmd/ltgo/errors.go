@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/X-for/ltgo/internal/client"
+	"github.com/X-for/ltgo/internal/config"
+)
+
+// printError 打印命令失败的原因，msg 为空时只打印 err；能识别的错误附带处理建议
+// 被 Ctrl-C 取消或超时的请求只给一句简短提示，不把 "context canceled" 甩给用户
+func printError(msg string, err error) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		fmt.Println("\n⛔ Cancelled.")
+	case errors.Is(err, context.DeadlineExceeded):
+		fmt.Println("\n⏱️  Timed out.")
+	case msg == "":
+		fmt.Println(err)
+	default:
+		fmt.Printf("%s: %v\n", msg, err)
+	}
+
+	if tip := advice(err); tip != "" {
+		fmt.Println("💡 " + tip)
+	}
+}
+
+// advice 针对常见错误告诉用户下一步该做什么
+func advice(err error) string {
+	switch {
+	case errors.Is(err, client.ErrNoCSRFToken):
+		return "Copy the whole Cookie header from the browser (it must include csrftoken) and run 'ltgo init' again."
+	case errors.Is(err, client.ErrNotSignedIn):
+		return "Your LeetCode session has expired or the cookie is invalid. Run 'ltgo init' to sign in again."
+	case errors.Is(err, client.ErrRateLimited):
+		return "LeetCode is throttling requests. Wait a minute and try again (see 'ltgo config set retries')."
+	case errors.Is(err, client.ErrPremiumRequired):
+		return "This problem needs a LeetCode Premium subscription on the signed-in account."
+	case errors.Is(err, client.ErrNotFound):
+		return "Check the problem ID or slug, or run 'ltgo sync' to refresh the local problem index."
+	case errors.Is(err, client.ErrServer):
+		return "LeetCode seems to be having trouble right now. Try again later."
+	}
+	return ""
+}
+
+// printConfigError config.Load 失败时的提示
+func printConfigError(err error) {
+	switch {
+	case errors.Is(err, config.ErrNotInitialized):
+		fmt.Println("Please run 'ltgo init' first.")
+		return
+	case errors.Is(err, config.ErrLocked):
+		fmt.Println("🔒 The stored cookies are encrypted. Run 'ltgo unlock' or set LTGO_PASSPHRASE.")
+		return
+	case errors.Is(err, config.ErrBadPassphrase):
+		fmt.Println("❌ Wrong passphrase.")
+		return
+	case errors.Is(err, config.ErrNoSessionDir):
+		fmt.Println("Cannot stay unlocked: $XDG_RUNTIME_DIR is missing or not private (owned by you, mode 0700).")
+		fmt.Println("Commands will ask for the passphrase each time; in scripts, set LTGO_PASSPHRASE.")
+		return
+	}
+	fmt.Printf("Error loading config: %v\n", err)
+}
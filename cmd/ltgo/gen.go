@@ -1,14 +1,18 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
 	"regexp"
+	"strings"
 
 	"github.com/X-for/ltgo/internal/client"
 	"github.com/X-for/ltgo/internal/config"
 	"github.com/X-for/ltgo/internal/generator"
 	"github.com/X-for/ltgo/internal/models"
+	"github.com/X-for/ltgo/internal/render"
+	"github.com/X-for/ltgo/internal/tui"
 	"github.com/spf13/cobra"
 )
 
@@ -33,12 +37,15 @@ Example:
 		if len(args) > 0 {
 			keyword = args[0]
 		}
-		runGen(keyword)
+		runGen(cmd.Context(), keyword)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(genCmd)
+	addRefreshFlag(genCmd)
+	addReadmeFlag(genCmd)
+	addDescLangFlag(genCmd)
 	genCmd.Flags().StringVarP(&difficulty, "difficulty", "d", "", "Difficulty (Easy, Medium, Hard)")
 	genCmd.Flags().StringVarP(&status, "status", "s", "", "Status (todo, solved, attempted)")
 	genCmd.Flags().StringVarP(&tag, "tag", "t", "", "Topic Tag (e.g. array, dp)")
@@ -50,14 +57,147 @@ func isNumeric(s string) bool {
 	return match
 }
 
-func runGen(keyword string) {
+func runGen(ctx context.Context, keyword string) {
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Println("Please run 'ltgo init' first.")
+		printConfigError(err)
 		return
 	}
 	c := client.New(cfg)
+	c.Refresh = refreshCache
 
+	targetQ, ok := lookupIndex(ctx, c, keyword)
+	if ok {
+		fmt.Printf("🎯 Found in local index: [%s] %s\n", targetQ.QuestionFrontendID, targetQ.Title)
+	} else {
+		targetQ, ok = searchQuestion(ctx, c, keyword)
+		if !ok {
+			return
+		}
+	}
+
+	// 获取详情并生成
+	fmt.Printf("Fetching details for '%s'...\n", targetQ.TitleSlug)
+	detail, err := c.GetQuestionDetail(ctx, targetQ.TitleSlug)
+	if err != nil {
+		printError("Failed to get details", err)
+		return
+	}
+
+	if err := generate(ctx, c, cfg, detail); err != nil {
+		fmt.Printf("Failed to generate: %v\n", err)
+		return
+	}
+
+	fmt.Println("Done! Happy Coding! 🚀")
+}
+
+// writeReadme 对应 gen / daily 的 --readme
+var writeReadme bool
+
+func addReadmeFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&writeReadme, "readme", false, "Also write the statement as Markdown, with images downloaded to assets/")
+}
+
+// descLangFlag 对应 gen / daily / show 的 --desc-lang
+var descLangFlag string
+
+func addDescLangFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&descLangFlag, "desc-lang", "", "Description language: en, zh or both (default: description_language config)")
+}
+
+// descLang --desc-lang 优先，其次是配置里的 description_language
+func descLang(cfg *config.Config) (string, error) {
+	lang := cfg.DescriptionLanguage
+	if descLangFlag != "" {
+		lang = descLangFlag
+	}
+	return lang, render.CheckDescLang(lang)
+}
+
+// generate 按配置 (含工作区的 output_dir / layout / template / readme) 生成题解文件
+func generate(ctx context.Context, c *client.Client, cfg *config.Config, detail *models.QuestionDetail) error {
+	lang, err := descLang(cfg)
+	if err != nil {
+		return err
+	}
+	wrap := generator.DefaultWrap
+	if cfg.Wrap != nil {
+		wrap = *cfg.Wrap
+	}
+	return generator.Generate(detail, cfg.OutputDir(), generator.Options{
+		Site:     cfg.Site,
+		Lang:     cfg.Language,
+		Layout:   cfg.Layout(),
+		Template: cfg.Template(),
+		Wrap:     wrap,
+		DescLang: lang,
+		Readme:   writeReadme || (cfg.Workspace != nil && cfg.Workspace.Readme),
+		Download: func(url string) ([]byte, error) {
+			return c.Download(ctx, url)
+		},
+	})
+}
+
+// lookupIndex 精确的 ID / slug 直接查本地索引，省掉一次网络搜索
+// 带了筛选条件时不走索引，交给服务端搜索
+func lookupIndex(ctx context.Context, c *client.Client, keyword string) (models.Question, bool) {
+	if difficulty != "" || status != "" || tag != "" {
+		return models.Question{}, false
+	}
+
+	key := keyword
+	if id != "" {
+		key = id
+	}
+
+	// 纯数字一定是 ID，索引里没有就增量同步一次
+	if isNumeric(key) {
+		q, err := resolveQuestion(ctx, c, key)
+		if err != nil {
+			return models.Question{}, false
+		}
+		return *q, true
+	}
+
+	// 其他关键词只有和 ID / slug 完全一致才算命中，不为了它去同步
+	if key == "" {
+		return models.Question{}, false
+	}
+	ix, err := c.Index()
+	if err != nil {
+		return models.Question{}, false
+	}
+	e, ok := ix.Lookup(key)
+	if !ok {
+		return models.Question{}, false
+	}
+	return e.Question(), true
+}
+
+// resolveQuestion 同 c.ResolveQuestion，需要先同步索引时打印进度
+// 第一次用的时候要拉几千道题，不打印的话看起来像卡住了
+func resolveQuestion(ctx context.Context, c *client.Client, key string) (*models.Question, error) {
+	synced := false
+	c.SyncProgress = func(done, total int) {
+		synced = true
+		if total > 0 {
+			fmt.Printf("\rSyncing problem index... %d/%d", done, total)
+		} else {
+			fmt.Print("\rSyncing problem index...")
+		}
+	}
+	defer func() {
+		c.SyncProgress = nil
+		if synced {
+			fmt.Println()
+		}
+	}()
+	return c.ResolveQuestion(ctx, key)
+}
+
+// searchQuestion 服务端搜索，多个结果时让用户细化
+func searchQuestion(ctx context.Context, c *client.Client, keyword string) (models.Question, bool) {
 	fmt.Printf("Searching for '%s'...\n", keyword)
 
 	// [修改 1] 改用服务端搜索 SearchQuestions (而不是本地 SearchQuestionsByKeyword)
@@ -69,16 +209,16 @@ func runGen(keyword string) {
 		Tag:        tag,
 		FrontendID: id,
 	}
-	matches, err := c.SearchQuestions(opts)
+	matches, err := c.SearchQuestions(ctx, opts)
 
 	if err != nil {
-		fmt.Printf("Search failed: %v\n", err)
-		return
+		printError("Search failed", err)
+		return models.Question{}, false
 	}
 
 	if len(matches) == 0 {
 		fmt.Println("❌ No questions found.")
-		return
+		return models.Question{}, false
 	}
 
 	var targetQ models.Question
@@ -100,31 +240,74 @@ func runGen(keyword string) {
 		// 只有一个模糊匹配结果，也就它了
 		targetQ = matches[0]
 		fmt.Printf("🎯 Found: [%s] %s\n", targetQ.QuestionFrontendID, targetQ.Title)
+	} else if tui.Interactive() {
+		// 终端里直接让用户选
+		q, ok := pickQuestion(matches)
+		if !ok {
+			return models.Question{}, false
+		}
+		targetQ = q
+		fmt.Printf("🎯 Selected: [%s] %s\n", targetQ.QuestionFrontendID, targetQ.Title)
 	} else {
-		// 多个结果，列出来让用户选
+		// 多个结果，列出来让用户细化 (输出不是终端时，比如在脚本里)
 		fmt.Println("Multiple questions found:")
 		for _, q := range matches {
 			fmt.Printf(" - [%s] %s\n", q.QuestionFrontendID, q.Title)
 		}
 		fmt.Println("\n⚠️  Please refine your search or use the exact ID.")
-		return
+		return models.Question{}, false
 	}
 
-	// 4. 获取详情并生成
-	fmt.Printf("Fetching details for '%s'...\n", targetQ.TitleSlug)
-	detail, err := c.GetQuestionDetail(targetQ.TitleSlug)
-	if err != nil {
-		fmt.Printf("Failed to get details: %v\n", err)
-		return
+	return targetQ, true
+}
+
+// pickQuestion 搜索结果有多个时的交互选择，预览难度和做题状态
+func pickQuestion(matches []models.Question) (models.Question, bool) {
+	opts := render.Options{Color: colorEnabled()}
+	items := make([]tui.Item, len(matches))
+	for i, q := range matches {
+		title := q.Title
+		if q.TranslatedTitle != "" && q.TranslatedTitle != q.Title {
+			title += " (" + q.TranslatedTitle + ")"
+		}
+		preview := opts.Difficulty(capitalize(q.Difficulty)) + " · " + statusText(q.Status)
+		if q.PaidOnly || q.IsPaidOnly {
+			preview += " · 🔒 Premium"
+		}
+		items[i] = tui.Item{
+			Label:   fmt.Sprintf("[%s] %s", q.QuestionFrontendID, title),
+			Preview: preview,
+			Filter:  q.QuestionFrontendID + " " + title + " " + q.TitleSlug,
+		}
 	}
 
-	cwd, _ := os.Getwd()
-	outputDir := fmt.Sprintf("%s/questions", cwd)
+	i, err := tui.Pick(fmt.Sprintf("%d questions found, pick one:", len(matches)), items)
+	if errors.Is(err, tui.ErrCancelled) {
+		fmt.Println("Cancelled.")
+		return models.Question{}, false
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return models.Question{}, false
+	}
+	return matches[i], true
+}
 
-	if err := generator.Generate(detail, outputDir, cfg.Site, cfg.Language); err != nil {
-		fmt.Printf("Failed to generate: %v\n", err)
-		return
+// capitalize EASY -> Easy
+func capitalize(s string) string {
+	if len(s) > 1 {
+		return s[:1] + strings.ToLower(s[1:])
 	}
+	return s
+}
 
-	fmt.Println("Done! Happy Coding! 🚀")
+// statusText 做题状态 (V2 是 TO_DO / AC / TRIED，旧版是 ac / notac / null)
+func statusText(status string) string {
+	switch strings.ToUpper(status) {
+	case "AC", "SOLVED":
+		return "✓ Solved"
+	case "TRIED", "ATTEMPTED", "NOTAC":
+		return "? Attempted"
+	}
+	return "Not started"
 }
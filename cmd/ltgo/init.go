@@ -2,29 +2,50 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/X-for/ltgo/internal/browser"
 	"github.com/X-for/ltgo/internal/client"
 	"github.com/X-for/ltgo/internal/config"
 	"github.com/spf13/cobra"
 )
 
+var (
+	fromBrowser    string
+	browserProfile string
+)
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize config and login",
-	Long:  "Setup your LeetCode account by inputting cookie.",
+	Long: `Setup your LeetCode account by inputting cookie.
+
+With --from-browser the cookie is read from a local browser profile instead
+(Linux only, needs the sqlite3 command). Log in to LeetCode in that browser first.`,
+	Example: `  ltgo init
+  ltgo init --from-browser firefox
+  ltgo init --from-browser chromium --browser-profile "~/.config/chromium/Profile 1"`,
 	Run: func(cmd *cobra.Command, args []string) {
-		runInit()
+		runInit(cmd.Context())
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(initCmd)
+	addBrowserFlags(initCmd)
+}
+
+// addBrowserFlags init 和 profile add 共用的 --from-browser / --browser-profile
+func addBrowserFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&fromBrowser, "from-browser", "", "Read the cookie from a local browser: "+strings.Join(browser.Supported, ", "))
+	cmd.Flags().StringVar(&browserProfile, "browser-profile", "", "Browser profile directory or cookie database (default: the browser's default profile)")
 }
 
-func runInit() {
+func runInit(ctx context.Context) {
 	reader := bufio.NewReader(os.Stdin)
 
 	// 1. 询问站点
@@ -35,12 +56,21 @@ func runInit() {
 		site = "cn"
 	}
 
-	// 2. 询问 Cookie
-	fmt.Println("Please paste your LeetCode Cookie (from browser developer tools):")
-	fmt.Println("(Include LEETCODE_SESSION and csrftoken)")
-	fmt.Print("> ")
-	cookie, _ := reader.ReadString('\n')
-	cookie = strings.TrimSpace(cookie)
+	// 2. 询问 Cookie (或者从浏览器里读)
+	var cookie string
+	if fromBrowser != "" {
+		var err error
+		if cookie, err = browserCookie(site); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return
+		}
+	} else {
+		fmt.Println("Please paste your LeetCode Cookie (from browser developer tools):")
+		fmt.Println("(Include LEETCODE_SESSION and csrftoken)")
+		fmt.Print("> ")
+		cookie, _ = reader.ReadString('\n')
+		cookie = strings.TrimSpace(cookie)
+	}
 
 	fmt.Println("\nVerifying your cookie...")
 
@@ -52,9 +82,10 @@ func runInit() {
 	}
 
 	c := client.New(tempCfg)
-	user, err := c.GetUser()
+	c.PersistCookies = false // 验证通过之前不写配置文件
+	user, err := c.GetUser(ctx)
 	if err != nil {
-		fmt.Printf("❌ Connection failed: %v\n", err)
+		printError("❌ Connection failed", err)
 		return
 	}
 
@@ -65,12 +96,45 @@ func runInit() {
 	}
 
 	fmt.Printf("✅ Welcome, %s!\n", user.Username)
+	if client.CookieValue(cookie, "csrftoken") == "" {
+		fmt.Println("⚠️  The cookie has no csrftoken, so 'ltgo run' and 'ltgo submit' will be rejected.")
+		fmt.Println("   Copy the whole Cookie header, including csrftoken.")
+	}
 
-	// 4. 保存
+	// 4. 保存到当前 profile (验证请求里服务端可能已经轮换了 Cookie)
+	// 重新登录已有的 profile 时保留它的语言等其他设置
+	if old, err := config.Load(); err == nil {
+		old.Site, old.Cookie = tempCfg.Site, tempCfg.Cookie
+		tempCfg = old
+	}
+	tempCfg.Cookie = c.Cookie()
 	if err := tempCfg.Save(); err != nil {
 		fmt.Printf("Failed to save config: %v\n", err)
 		return
 	}
 
-	fmt.Println("🎉 Configuration saved successfully!")
+	fmt.Printf("🎉 Configuration saved to profile '%s'!\n", tempCfg.Profile)
+}
+
+// browserCookie 从本地浏览器读出 LeetCode 站点的 Cookie
+func browserCookie(site string) (string, error) {
+	host := "leetcode.cn"
+	if site == "com" {
+		host = "leetcode.com"
+	}
+	profile := browserProfile
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(profile, "~/") {
+		profile = filepath.Join(home, profile[2:])
+	}
+
+	fmt.Printf("Reading %s cookies from %s...\n", host, fromBrowser)
+	cookies, err := browser.Cookies(fromBrowser, host, profile)
+	if err != nil {
+		return "", err
+	}
+	cookie := browser.CookieString(cookies)
+	if client.CookieValue(cookie, "LEETCODE_SESSION") == "" {
+		return "", fmt.Errorf("no LEETCODE_SESSION cookie for %s in %s, log in to LeetCode in the browser first", host, fromBrowser)
+	}
+	return cookie, nil
 }
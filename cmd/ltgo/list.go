@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"text/tabwriter" // 用这个对齐输出，超好用
 
@@ -14,14 +16,20 @@ import (
 var (
 	listPage  int
 	listLimit int
+	listSort  string
+	listOrder string
+	listRange string
 )
 
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List questions",
 	Long:  `List questions with pagination. Default: page 1, 50 questions per page.`,
+	Example: `  ltgo list -d hard -s todo
+  ltgo list -t dynamic-programming --sort acceptance --order desc
+  ltgo list --range 100-200 -p 2`,
 	Run: func(cmd *cobra.Command, args []string) {
-		runList()
+		runList(cmd.Context())
 	},
 }
 
@@ -29,33 +37,75 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 	listCmd.Flags().IntVarP(&listPage, "page", "p", 1, "Page number")
 	listCmd.Flags().IntVarP(&listLimit, "limit", "l", 50, "Questions per page")
+	// 过滤条件和 gen 共用同一组变量
+	listCmd.Flags().StringVarP(&difficulty, "difficulty", "d", "", "Difficulty (Easy, Medium, Hard)")
+	listCmd.Flags().StringVarP(&status, "status", "s", "", "Status (todo, solved, attempted)")
+	listCmd.Flags().StringVarP(&tag, "tag", "t", "", "Topic Tag (e.g. array, dp)")
+	listCmd.Flags().StringVar(&listSort, "sort", "", "Sort by: id, acceptance, difficulty")
+	listCmd.Flags().StringVar(&listOrder, "order", "asc", "Sort order: asc, desc")
+	listCmd.Flags().StringVar(&listRange, "range", "", "Only problems whose ID is in this range, e.g. 100-200")
 }
 
-func runList() {
+func runList(ctx context.Context) {
 	// 1. 加载配置
 	cfg, err := config.Load()
 	if err != nil {
-		fmt.Println("Please run 'ltgo init' first.")
+		printConfigError(err)
 		return
 	}
 
 	c := client.New(cfg)
 
-	// 2. 计算分页参数
+	// 2. 检查参数，计算分页
+	if !client.ValidSort(listSort) {
+		fmt.Printf("Error: unknown sort field '%s' (use id, acceptance or difficulty)\n", listSort)
+		return
+	}
+	if listOrder != "asc" && listOrder != "desc" {
+		fmt.Printf("Error: --order must be asc or desc\n")
+		return
+	}
+	minID, maxID, err := parseRange(listRange)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
 	if listPage < 1 {
 		listPage = 1
 	}
 	if listLimit < 1 {
 		listLimit = 50
 	}
-	skip := (listPage - 1) * listLimit
-	fmt.Printf("Fetching questions (Page %d)...\n", listPage)
 
-	// 3. 获取题目 (我们先写死获取前 50 题，后面可以加 flag 控制)
-	fmt.Println("Fetching questions...")
-	resp, err := c.GetQuestions(listLimit, skip)
+	// 3. 获取题目
+	fmt.Printf("Fetching questions (Page %d)...\n", listPage)
+	page, err := c.QueryQuestions(ctx, client.SearchOptions{
+		Difficulty: difficulty,
+		Status:     status,
+		Tag:        tag,
+		MinID:      minID,
+		MaxID:      maxID,
+		Sort:       listSort,
+		Descending: listOrder == "desc",
+		Skip:       (listPage - 1) * listLimit,
+		Limit:      listLimit,
+	})
 	if err != nil {
-		fmt.Printf("Failed to fetch questions: %v\n", err)
+		printError("Failed to fetch questions", err)
+		return
+	}
+
+	// 旧版接口不返回总数 (Total 为 0)，这时不知道一共几页，只能看这一页是不是满的
+	pages := (page.Total + listLimit - 1) / listLimit
+	if len(page.Questions) == 0 {
+		switch {
+		case page.Total > 0:
+			fmt.Printf("Page %d is past the end (%d pages, %d questions).\n", listPage, pages, page.Total)
+		case listPage > 1:
+			fmt.Printf("Page %d is past the end.\n", listPage)
+		default:
+			fmt.Println("No questions match the filters.")
+		}
 		return
 	}
 
@@ -64,17 +114,11 @@ func runList() {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 
 	// 表头
-	fmt.Fprintln(w, "Status\tID\tTitle\tDifficulty")
-	fmt.Fprintln(w, "------\t--\t-----\t----------")
-
-	// 适配 V2 和旧版数据
-	questions := resp.Data.ProblemsetQuestionListV2.Questions
-	if len(questions) == 0 {
-		questions = resp.Data.ProblemsetQuestionList.Questions
-	}
+	fmt.Fprintln(w, "Status\tID\tTitle\tDifficulty\tAcceptance")
+	fmt.Fprintln(w, "------\t--\t-----\t----------\t----------")
 
 	// 遍历 questions 打印
-	for _, q := range questions {
+	for _, q := range page.Questions {
 		//fmt.Printf("DEBUG: ID=%s Status=%v\n", q.QuestionFrontendID, q.Status)
 		status := " "
 		// 状态码转换 (V2 返回的是 TO_DO / AC)
@@ -97,9 +141,55 @@ func runList() {
 			title = fmt.Sprintf("%s (%s)", q.TranslatedTitle, q.Title)
 		}
 
-		fmt.Fprintf(w, "[%s]\t%s\t%s\t%s\n", status, q.QuestionFrontendID, title, diff)
+		fmt.Fprintf(w, "[%s]\t%s\t%s\t%s\t%s\n", status, q.QuestionFrontendID, title, diff, acceptance(q.AcRate))
 	}
 
 	w.Flush()
-	fmt.Printf("\n(Show more: ltgo list -p %d)\n", listPage+1)
+	more := listPage < pages
+	if page.Total > 0 {
+		fmt.Printf("\nPage %d of %d (%d questions)\n", listPage, pages, page.Total)
+	} else {
+		fmt.Printf("\nPage %d\n", listPage)
+		more = len(page.Questions) == listLimit
+	}
+	if more {
+		fmt.Printf("(Show more: ltgo list -p %d)\n", listPage+1)
+	}
+}
+
+// parseRange 解析 --range: "100-200"、"100-" (100 及以后)、"-200"，或者单个 ID
+func parseRange(s string) (lo, hi int, err error) {
+	if s == "" {
+		return 0, 0, nil
+	}
+	from, to, found := strings.Cut(s, "-")
+	if !found {
+		to = from
+	}
+	bad := fmt.Errorf("invalid range '%s' (expected e.g. 100-200)", s)
+	if from != "" {
+		if lo, err = strconv.Atoi(strings.TrimSpace(from)); err != nil || lo < 1 {
+			return 0, 0, bad
+		}
+	}
+	if to != "" {
+		if hi, err = strconv.Atoi(strings.TrimSpace(to)); err != nil || hi < 1 {
+			return 0, 0, bad
+		}
+	}
+	if (lo == 0 && hi == 0) || (hi > 0 && lo > hi) {
+		return 0, 0, bad
+	}
+	return lo, hi, nil
+}
+
+// acceptance 通过率显示成百分数 (V2 返回 0~1 的小数，旧版已经是百分数)
+func acceptance(rate float64) string {
+	if rate <= 0 {
+		return "-"
+	}
+	if rate <= 1 {
+		rate *= 100
+	}
+	return fmt.Sprintf("%.1f%%", rate)
 }
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/X-for/ltgo/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage account profiles",
+	Long: `Manage several LeetCode accounts (e.g. a leetcode.cn and a leetcode.com one).
+
+Every profile has its own cookie, site and language. Commands use the default
+profile unless --profile is given.`,
+	Example: `  ltgo profile list
+  ltgo profile add work --from-browser firefox
+  ltgo profile use work
+  ltgo list --profile default`,
+	Run: func(cmd *cobra.Command, args []string) {
+		listProfiles()
+	},
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		listProfiles()
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use [name]",
+	Short: "Set the default profile",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		useProfile(args[0])
+	},
+}
+
+var profileAddCmd = &cobra.Command{
+	Use:   "add [name]",
+	Short: "Add a profile and log in to it",
+	Long:  "Add a profile and log in to it (same prompts and flags as 'ltgo init').",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		f, err := config.LoadFile()
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			return
+		}
+		if _, ok := f.Profiles[args[0]]; ok {
+			fmt.Printf("Profile '%s' already exists. Use 'ltgo init --profile %s' to log in again.\n", args[0], args[0])
+			return
+		}
+		config.Overrides.Profile = args[0]
+		runInit(cmd.Context())
+	},
+}
+
+var profileRemoveCmd = &cobra.Command{
+	Use:     "remove [name]",
+	Aliases: []string{"rm"},
+	Short:   "Remove a profile",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		removeProfile(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileListCmd, profileUseCmd, profileAddCmd, profileRemoveCmd)
+	addBrowserFlags(profileAddCmd)
+}
+
+func listProfiles() {
+	f, err := config.LoadFile()
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		return
+	}
+	if len(f.Profiles) == 0 {
+		fmt.Println("No profiles yet. Run 'ltgo init' to create one.")
+		return
+	}
+
+	active := f.Active()
+	for _, name := range f.Names() {
+		p := f.Profiles[name]
+		mark := " "
+		if name == active {
+			mark = "*"
+		}
+		note := ""
+		if name == f.Default {
+			note = " (default)"
+		}
+		fmt.Printf("%s %-12s site=%-3s lang=%s%s\n", mark, name, p.Site, p.Language, note)
+	}
+}
+
+func useProfile(name string) {
+	f, err := config.LoadFile()
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		return
+	}
+	if _, ok := f.Profiles[name]; !ok {
+		fmt.Printf("Error: profile '%s' not found (see 'ltgo profile list')\n", name)
+		return
+	}
+	f.Default = name
+	if err := f.Save(); err != nil {
+		fmt.Printf("Error saving config: %v\n", err)
+		return
+	}
+	fmt.Printf("✅ Default profile is now '%s'\n", name)
+}
+
+func removeProfile(name string) {
+	f, err := config.LoadFile()
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		return
+	}
+	if _, ok := f.Profiles[name]; !ok {
+		fmt.Printf("Error: profile '%s' not found (see 'ltgo profile list')\n", name)
+		return
+	}
+	delete(f.Profiles, name)
+
+	// 删掉的是默认 profile 时，换成剩下的第一个
+	if f.Default == name {
+		f.Default = ""
+		if names := f.Names(); len(names) > 0 {
+			f.Default = names[0]
+		}
+	}
+	if err := f.Save(); err != nil {
+		fmt.Printf("Error saving config: %v\n", err)
+		return
+	}
+
+	fmt.Printf("🗑️  Removed profile '%s'\n", name)
+	if f.Default != "" {
+		fmt.Printf("Default profile: %s\n", f.Default)
+	}
+}
@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/X-for/ltgo/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -15,13 +19,29 @@ var rootCmd = &cobra.Command{
 }
 
 // Execute 是 main.go 调用的入口
+// Ctrl-C 会取消 cmd.Context()，正在进行的请求和轮询立刻停下；再按一次直接退出
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		os.Exit(1)
 	}
+	if ctx.Err() != nil {
+		os.Exit(130)
+	}
 }
 
 func init() {
-	// 在这里可以定义全局 flag，比如 --site cn
-	// rootCmd.PersistentFlags().StringVar(...)
+	// 全局 flag，对所有子命令生效 (优先于环境变量和配置文件，见 config.Load)
+	flags := rootCmd.PersistentFlags()
+	flags.StringVar(&config.Overrides.Profile, "profile", "", "Use this profile instead of the default one (see 'ltgo profile')")
+	flags.StringVar(&config.Overrides.ConfigPath, "config", "", "Config file (default ~/.ltgo/config.json, env "+config.EnvConfig+")")
+	flags.StringVar(&config.Overrides.Site, "site", "", "LeetCode site for this run: cn or com (env "+config.EnvSite+")")
+	flags.StringVar(&config.Overrides.Language, "lang", "", "Solution language for this run, e.g. golang (env "+config.EnvLanguage+")")
+	flags.StringVar(&config.Overrides.CookieFile, "cookie-file", "", "Read the LeetCode cookie from this file (env "+config.EnvCookie+" holds the cookie itself)")
 }
@@ -1,115 +1,91 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/X-for/ltgo/internal/client"
 	"github.com/X-for/ltgo/internal/config"
-	"github.com/X-for/ltgo/internal/generator"
+	"github.com/X-for/ltgo/internal/problem"
 	"github.com/spf13/cobra"
 )
 
 var runCmd = &cobra.Command{
 	Use:   "run [file]",
 	Short: "Run code on LeetCode",
-	Args:  cobra.ExactArgs(1),
+	Long: `Run the solution on LeetCode against the problem's examples.
+
+Use --testcase (one case per flag, parameters separated by newlines or a
+literal \n) or --testcase-file to send your own cases instead. Cases saved in
+the .testcases file next to the solution are always sent as well.`,
+	Example: `  ltgo run questions/1_two-sum.go
+  ltgo run questions/1_two-sum.go --testcase '[3,3]\n6'
+  ltgo run questions/1_two-sum.go --testcase-file edge.txt`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		startRun(args[0])
+		startRun(cmd.Context(), args[0])
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(runCmd)
+	addRefreshFlag(runCmd)
+	runCmd.Flags().StringArrayVar(&testcaseArgs, "testcase", nil, "Custom test case, parameters separated by newlines (repeatable)")
+	runCmd.Flags().StringVar(&testcaseFile, "testcase-file", "", "Read custom test cases from a file (blank line between cases)")
 }
 
-func startRun(filePath string) {
-	// 1. 检查文件是否存在
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		fmt.Printf("File not found: %s\n", filePath)
+func startRun(ctx context.Context, filePath string) {
+	// 1. 初始化 Client
+	cfg, err := config.Load()
+	if err != nil {
+		printConfigError(err)
 		return
 	}
+	c := client.New(cfg)
+	c.Refresh = refreshCache
 
-	// 2. 尝试解析 Slug
-	var slug string
-	// 先尝试从文件元数据里读
-	metaSlug, err := generator.ParseSlugFromMeta(filePath)
-	if err == nil && metaSlug != "" {
-		slug = metaSlug
-		// fmt.Printf("Found slug from metadata: %s\n", slug)
-	} else {
-		// 读不到(旧文件)则回退到文件名解析
-		filename := filepath.Base(filePath)
-		parts := strings.Split(filename, "_")
-		if len(parts) >= 2 {
-			slugWithExt := parts[1]
-			slug = strings.TrimSuffix(slugWithExt, ".go")
-		} else {
-			fmt.Println("Could not parse slug from metadata or filename (expected ID_slug.go).")
-			return
-		}
-	}
-
-	// 获取编码语言
-	lang, err := generator.ParseLangFromMeta(filePath)
-	if err != nil || lang == "" {
-		// 如果没找到元数据，尝试根据后缀推断 (兼容旧文件或手写文件)
-		ext := strings.TrimPrefix(filepath.Ext(filePath), ".")
-		// 简单的反向查找
-		for k, v := range generator.SupportedLangs {
-			if v.Extension == ext {
-				lang = k
-				break
-			}
-		}
-		if lang == "" {
-			lang = "golang" // 最后的保底
-		}
-	}
-
-	// 3. 读取代码
-	code, err := generator.ReadSolution(filePath)
+	// 2. 解析题解文件 (slug / 语言 / 代码)
+	sol, err := loadSolution(ctx, c, filePath)
 	if err != nil {
-		fmt.Printf("Failed to read solution: %v\n", err)
+		printError("", err)
 		return
 	}
+	code, lang := sol.Code, sol.Lang
 
-	// 4. 初始化 Client
-	cfg, err := config.Load()
+	// 3. 获取题目详情 (为了拿 Test Case 和 ID)
+	fmt.Printf("Fetching question info for '%s'...\n", sol.Slug)
+	q, err := fetchQuestion(ctx, c, sol.Slug)
 	if err != nil {
-		fmt.Println("Please run 'ltgo init' first.")
+		printError("Failed to get question info", err)
 		return
 	}
-	c := client.New(cfg)
 
-	// 5. 获取题目详情 (为了拿 Test Case 和 ID)
-	fmt.Printf("Fetching question info for '%s'...\n", slug)
-	q, err := c.GetQuestionDetail(slug)
+	// 4. 组装测试用例
+	cases, err := runCases(sol, q)
 	if err != nil {
-		fmt.Printf("Failed to get question info: %v\n", err)
+		printError("", err)
 		return
 	}
 
-	// 6. 提交运行
-	fmt.Printf("🚀 Sending code (%s) to LeetCode...\n", lang)
-	interpretID, err := c.RunCode(q, code, lang)
+	// 5. 提交运行
+	fmt.Printf("🚀 Sending code (%s) to LeetCode with %d test cases...\n", lang, len(cases))
+	interpretID, err := c.RunCode(ctx, q, code, lang, problem.DataInput(cases))
 	if err != nil {
-		fmt.Printf("Failed to submit run: %v\n", err)
+		printError("Failed to submit run", err)
 		return
 	}
 
-	// 7. 轮询结果
+	// 6. 轮询结果
 	fmt.Print("Waiting for result...")
-	res, err := c.CheckResult(interpretID)
+	res, err := c.CheckResult(ctx, interpretID)
 	if err != nil {
-		fmt.Printf("\nError checking result: %v\n", err)
+		printError("\nError checking result", err)
 		return
 	}
-	fmt.Println("\n")
+	fmt.Print("\n\n")
 
-	// 8. 漂亮地打印结果
+	// 7. 漂亮地打印结果
 	// 编译错误
 	if res.CompileError != "" || res.FullCompileError != "" {
 		fmt.Println("❌ Compile Error:")
@@ -136,25 +112,24 @@ func startRun(filePath string) {
 
 	// 打印总结
 	if (res.StatusMsg == "Accepted" || res.StatusMsg == "Finished") && res.CorrectAnswer {
-		fmt.Println("✅ Accepted\n")
+		fmt.Print("✅ Accepted\n\n")
 	} else if res.StatusMsg == "Compile Error" {
 		// ... (其实前面已经拦截了编译错误)
 	} else {
 		// 其他情况统统算 Wrong Answer (只要代码跑完了但 CorrectAnswer 是 false)
-		fmt.Println("❌ Wrong Answer\n")
+		fmt.Print("❌ Wrong Answer\n\n")
 		// 如果想看原始状态，可以保留: fmt.Printf("(Status: %s)\n", res.StatusMsg)
 	}
 
 	// 详细打印每个 Case
 	for i := 0; i < count; i++ {
 		input := ""
-		// [修改 2] 尝试获取 Input，如果 API 没返回，就显示 SampleTestCase
+		// [修改 2] 尝试获取 Input，如果 API 没返回，就显示我们发送的用例
 		if i < len(res.InputFormatted) {
 			input = res.InputFormatted[i]
-		} else if i == 0 && q.SampleTestCase != "" {
-			// 只有第一个 case 我们能确信是 SampleTestCase
-			// 简单的格式化一下，把换行符换成空格，避免太长
-			input = strings.ReplaceAll(q.SampleTestCase, "\n", " ")
+		} else if i < len(cases) {
+			// 把参数拼成一行，避免太长
+			input = strings.Join(cases[i].Args, " ")
 		}
 
 		output := ""
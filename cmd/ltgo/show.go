@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/X-for/ltgo/internal/client"
+	"github.com/X-for/ltgo/internal/config"
+	"github.com/X-for/ltgo/internal/models"
+	"github.com/X-for/ltgo/internal/render"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var showRaw bool
+
+var showCmd = &cobra.Command{
+	Use:   "show [id|slug|file]",
+	Short: "Show a problem description in the terminal",
+	Long: `Show a problem description in the terminal, with examples, lists and tables.
+Long descriptions are shown through $PAGER (default: less).`,
+	Example: `  ltgo show 1
+  ltgo show two-sum
+  ltgo show "Two Sum"
+  ltgo show questions/1_two-sum.go
+  ltgo show 1 --raw > two-sum.html`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runShow(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(showCmd)
+	addRefreshFlag(showCmd)
+	addDescLangFlag(showCmd)
+	showCmd.Flags().BoolVar(&showRaw, "raw", false, "Print the description HTML as returned by LeetCode")
+}
+
+func runShow(ctx context.Context, key string) {
+	cfg, err := config.Load()
+	if err != nil {
+		printConfigError(err)
+		return
+	}
+	lang, err := descLang(cfg)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	c := client.New(cfg)
+	c.Refresh = refreshCache
+
+	slug, err := showSlug(ctx, c, key)
+	if err != nil {
+		printError("❌ Failed to find the problem", err)
+		return
+	}
+	q, err := c.GetQuestionDetail(ctx, slug)
+	if err != nil {
+		printError("Failed to get details", err)
+		return
+	}
+
+	content := render.Description(q, lang)
+	if showRaw {
+		fmt.Println(content)
+		return
+	}
+
+	opts := render.Options{Color: colorEnabled()}
+	page(showHeader(c, q, lang, opts) + "\n\n" + render.Terminal(content, opts) + "\n")
+}
+
+// showSlug 参数可以是前端 ID、slug、英文标题 ("Two Sum") 或者本地的题解文件
+func showSlug(ctx context.Context, c *client.Client, key string) (string, error) {
+	if info, err := os.Stat(key); err == nil && !info.IsDir() {
+		return parseSlug(ctx, c, key)
+	}
+	if isNumeric(key) {
+		q, err := resolveQuestion(ctx, c, key)
+		if err != nil {
+			return "", err
+		}
+		return q.TitleSlug, nil
+	}
+	return slugify(key), nil
+}
+
+// slugify 英文标题转成 LeetCode 的 slug: 小写，去掉标点，空格连成 "-"
+// ("Pow(x, n)" -> "powx-n")；本来就是 slug 的原样返回
+func slugify(title string) string {
+	var words []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	}) {
+		w = strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, w)
+		if w != "" {
+			words = append(words, w)
+		}
+	}
+	return strings.Join(words, "-")
+}
+
+func showHeader(c *client.Client, q *models.QuestionDetail, lang string, opts render.Options) string {
+	head := opts.Bold(fmt.Sprintf("%s. %s", q.QuestionFrontendID, render.Title(q, lang))) + "  " + opts.Difficulty(q.Difficulty)
+	if q.IsPaidOnly {
+		head += "  🔒"
+	}
+	return head + "\n" + opts.Dim(fmt.Sprintf("%s/problems/%s/", c.BaseURL, q.TitleSlug))
+}
+
+// colorEnabled 输出到终端且没有设置 NO_COLOR 时才用颜色
+func colorEnabled() bool {
+	return os.Getenv("NO_COLOR") == "" && term.IsTerminal(int(os.Stdout.Fd()))
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"two-sum", "two-sum"},
+		{"Two Sum", "two-sum"},
+		{"  Two   Sum ", "two-sum"},
+		{"Pow(x, n)", "powx-n"},
+		{"Pascal's Triangle II", "pascals-triangle-ii"},
+		{"3Sum", "3sum"},
+		{"min_stack", "min-stack"},
+	}
+	for _, tt := range tests {
+		if got := slugify(tt.in); got != tt.want {
+			t.Errorf("slugify(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
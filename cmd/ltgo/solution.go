@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/X-for/ltgo/internal/client"
+	"github.com/X-for/ltgo/internal/generator"
+	"github.com/X-for/ltgo/internal/models"
+)
+
+// solution 本地题解文件 (run / submit 共用)
+type solution struct {
+	Path string
+	Slug string
+	Lang string
+	Code string
+}
+
+// loadSolution 读取题解文件并解析出 slug、语言和代码
+// slug 的来源优先级: @lc slug 元数据 > 文件名 ID_slug.ext > 用 ID 查本地索引
+func loadSolution(ctx context.Context, c *client.Client, filePath string) (*solution, error) {
+	// 1. 检查文件是否存在
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("file not found: %s", filePath)
+	}
+
+	// 2. 尝试解析 Slug
+	slug, err := parseSlug(ctx, c, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// 获取编码语言
+	lang, err := generator.ParseLangFromMeta(filePath)
+	if err != nil || lang == "" {
+		// 如果没找到元数据，尝试根据后缀推断 (兼容旧文件或手写文件)
+		ext := strings.TrimPrefix(filepath.Ext(filePath), ".")
+		// 简单的反向查找
+		for k, v := range generator.SupportedLangs {
+			if v.Extension == ext {
+				lang = k
+				break
+			}
+		}
+		if lang == "" {
+			lang = "golang" // 最后的保底
+		}
+	}
+
+	// 3. 读取代码
+	code, err := generator.ReadSolution(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read solution: %w", err)
+	}
+
+	return &solution{Path: filePath, Slug: slug, Lang: lang, Code: code}, nil
+}
+
+func parseSlug(ctx context.Context, c *client.Client, filePath string) (string, error) {
+	// 先尝试从文件元数据里读
+	if slug, err := generator.ParseSlugFromMeta(filePath); err == nil && slug != "" {
+		return slug, nil
+	}
+
+	// 读不到(旧文件)则回退到文件名解析
+	name := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	parts := strings.SplitN(name, "_", 2)
+	if len(parts) == 2 && parts[1] != "" {
+		return parts[1], nil
+	}
+
+	// 只有 ID (元数据里的 id= 或者文件名就是 ID)，去本地索引里换 slug
+	id, err := generator.ParseIDFromMeta(filePath)
+	if err != nil || id == "" {
+		id = parts[0]
+	}
+	if isNumeric(id) {
+		q, err := resolveQuestion(ctx, c, id)
+		if err != nil {
+			return "", err
+		}
+		return q.TitleSlug, nil
+	}
+
+	return "", fmt.Errorf("could not parse slug from metadata or filename (expected ID_slug.ext)")
+}
+
+// fetchQuestion 获取题目详情 (为了拿 Test Case 和 ID)
+// 详情里缺后端 ID 时用本地索引补上，提交要用
+func fetchQuestion(ctx context.Context, c *client.Client, slug string) (*models.QuestionDetail, error) {
+	q, err := c.GetQuestionDetail(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if q.QuestionID == "" {
+		if ix, err := c.Index(); err == nil {
+			if e, ok := ix.BySlug(slug); ok {
+				q.QuestionID = e.QuestionID
+			}
+		}
+	}
+	return q, nil
+}
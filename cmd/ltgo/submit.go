@@ -1,117 +1,97 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
+	"time"
 
 	"github.com/X-for/ltgo/internal/client"
 	"github.com/X-for/ltgo/internal/config"
-	"github.com/X-for/ltgo/internal/generator"
+	"github.com/X-for/ltgo/internal/problem"
 	"github.com/spf13/cobra"
 )
 
+var forceSubmit bool
+
 var submitCmd = &cobra.Command{
 	Use:   "submit [file]",
 	Short: "Submit code to LeetCode",
-	Args:  cobra.ExactArgs(1),
+	Long: `Submit the solution to LeetCode.
+
+When a submission fails (Wrong Answer, Time Limit Exceeded, Runtime Error...),
+the failing input is appended to the .testcases file next to the solution, so
+'ltgo run' and 'ltgo test' replay it from then on. Go solutions are checked
+against those saved cases locally before submitting; use --force to skip.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		startSubmit(args[0])
+		startSubmit(cmd.Context(), args[0])
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(submitCmd)
+	addRefreshFlag(submitCmd)
+	submitCmd.Flags().BoolVarP(&forceSubmit, "force", "f", false, "Submit even if saved test cases still fail locally")
 }
 
-func startSubmit(filePath string) {
-
-	// 1. 检查文件是否存在
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		fmt.Printf("File not found: %s\n", filePath)
+func startSubmit(ctx context.Context, filePath string) {
+	// 1. 初始化 Client
+	cfg, err := config.Load()
+	if err != nil {
+		printConfigError(err)
 		return
 	}
+	c := client.New(cfg)
+	c.Refresh = refreshCache
 
-	// 2. 尝试解析 Slug
-	var slug string
-	// 先尝试从文件元数据里读
-	metaSlug, err := generator.ParseSlugFromMeta(filePath)
-	if err == nil && metaSlug != "" {
-		slug = metaSlug
-		// fmt.Printf("Found slug from metadata: %s\n", slug)
-	} else {
-		// 读不到(旧文件)则回退到文件名解析
-		filename := filepath.Base(filePath)
-		parts := strings.Split(filename, "_")
-		if len(parts) >= 2 {
-			slugWithExt := parts[1]
-			slug = strings.TrimSuffix(slugWithExt, ".go")
-		} else {
-			fmt.Println("Could not parse slug from metadata or filename (expected ID_slug.go).")
-			return
-		}
-	}
-
-	// 获取编码语言
-	lang, err := generator.ParseLangFromMeta(filePath)
-	if err != nil || lang == "" {
-		// 如果没找到元数据，尝试根据后缀推断 (兼容旧文件或手写文件)
-		ext := strings.TrimPrefix(filepath.Ext(filePath), ".")
-		// 简单的反向查找
-		for k, v := range generator.SupportedLangs {
-			if v.Extension == ext {
-				lang = k
-				break
-			}
-		}
-		if lang == "" {
-			lang = "golang" // 最后的保底
-		}
-	}
-
-	// 3. 读取代码
-	code, err := generator.ReadSolution(filePath)
+	// 2. 解析题解文件 (slug / 语言 / 代码)
+	sol, err := loadSolution(ctx, c, filePath)
 	if err != nil {
-		fmt.Printf("Failed to read solution: %v\n", err)
+		printError("", err)
 		return
 	}
+	code, lang := sol.Code, sol.Lang
 
-	// 4. 初始化 Client
-	cfg, err := config.Load()
+	// 3. 获取题目详情 (为了拿 Test Case 和 ID)
+	fmt.Printf("Fetching question info for '%s'...\n", sol.Slug)
+	q, err := fetchQuestion(ctx, c, sol.Slug)
 	if err != nil {
-		fmt.Println("Please run 'ltgo init' first.")
+		printError("Failed to get question info", err)
 		return
 	}
-	c := client.New(cfg)
 
-	// 5. 获取题目详情 (为了拿 Test Case 和 ID)
-	fmt.Printf("Fetching question info for '%s'...\n", slug)
-	q, err := c.GetQuestionDetail(slug)
-	if err != nil {
-		fmt.Printf("Failed to get question info: %v\n", err)
-		return
+	// 4. 之前失败过的用例还没过就不提交了
+	if !forceSubmit {
+		if err := replaySaved(ctx, sol, q); err != nil {
+			if ctx.Err() != nil {
+				printError("", err)
+				return
+			}
+			fmt.Println(err)
+			fmt.Println("Fix them first, or use --force to submit anyway.")
+			return
+		}
 	}
 
-	// 6. 提交代码
+	// 5. 提交代码
 	fmt.Printf("🚀 Submitting to LeetCode...\n")
-	subID, err := c.SubmitCode(q, code, lang)
+	subID, err := c.SubmitCode(ctx, q, code, lang)
 	if err != nil {
-		fmt.Printf("Failed to submit: %v\n", err)
+		printError("Failed to submit", err)
 		return
 	}
 	fmt.Printf("Submission ID: %d\n", subID)
 
-	// 7. 轮询结果
+	// 6. 轮询结果
 	fmt.Print("Waiting for result...")
-	res, err := c.CheckSubmission(subID)
+	res, err := c.CheckSubmission(ctx, subID)
 	if err != nil {
-		fmt.Printf("\nError checking result: %v\n", err)
+		printError("\nError checking result", err)
 		return
 	}
-	fmt.Println("\n")
+	fmt.Print("\n\n")
 
-	// 8. 打印结果
+	// 7. 打印结果
 	if res.CompileError != "" {
 		fmt.Println("❌ Compile Error:")
 		fmt.Println(res.FullCompileError)
@@ -121,6 +101,10 @@ func startSubmit(filePath string) {
 	if res.RuntimeError != "" {
 		fmt.Println("❌ Runtime Error:")
 		fmt.Println(res.RuntimeError)
+		if res.InputFormatted != "" {
+			fmt.Printf("Last Input: %s\n", res.InputFormatted)
+		}
+		saveFailedCase(sol, res)
 		return
 	}
 
@@ -137,6 +121,7 @@ func startSubmit(filePath string) {
 		if res.StdOutput != "" {
 			fmt.Printf("Stdout:   %s\n", res.StdOutput)
 		}
+		saveFailedCase(sol, res)
 	} else {
 		fmt.Printf("Status: %s\n", res.StatusMsg)
 		fmt.Printf("Passed: %d/%d cases\n", res.TotalCorrect, res.TotalTestcases)
@@ -144,5 +129,24 @@ func startSubmit(filePath string) {
 		if res.InputFormatted != "" {
 			fmt.Printf("Last Input: %s\n", res.InputFormatted)
 		}
+		saveFailedCase(sol, res)
+	}
+}
+
+// saveFailedCase 把出错的用例追加到题解旁边的 .testcases 文件
+func saveFailedCase(sol *solution, res *client.SubmitCheckResponse) {
+	c, ok := problem.FailedCase(res.LastTestcase, res.InputFormatted, res.ExpectedOutput)
+	if !ok {
+		return
+	}
+	path := problem.TestcasePath(sol.Path)
+	comment := fmt.Sprintf("%s (%s)", res.StatusMsg, time.Now().Format("2006-01-02 15:04"))
+	added, err := problem.AppendTestcase(path, c, comment)
+	if err != nil {
+		fmt.Printf("⚠️  Failed to save the failing case: %v\n", err)
+		return
+	}
+	if added {
+		fmt.Printf("\n📝 Saved the failing case to %s\n", path)
 	}
 }
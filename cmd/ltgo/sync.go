@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/X-for/ltgo/internal/client"
+	"github.com/X-for/ltgo/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var syncFull bool
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync the local problem index",
+	Long: `Download the problem catalog into a local index (~/.ltgo/index-<site>.json).
+
+gen, run and submit use the index to resolve IDs and slugs without a network
+round trip. By default the catalog is only downloaded again when the number
+of problems on the server has changed; use --full to rebuild the index (e.g.
+to refresh solved status).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runSync(cmd.Context())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().BoolVar(&syncFull, "full", false, "Rebuild the whole index even if the problem count has not changed")
+}
+
+func runSync(ctx context.Context) {
+	cfg, err := config.Load()
+	if err != nil {
+		printConfigError(err)
+		return
+	}
+	c := client.New(cfg)
+
+	fmt.Println("Syncing problem index...")
+	added, err := c.SyncIndex(ctx, syncFull, func(done, total int) {
+		fmt.Printf("\r  fetched %d/%d", done, total)
+	})
+	fmt.Println()
+	if err != nil {
+		printError("Sync failed", err)
+		return
+	}
+
+	ix, _ := c.Index()
+	fmt.Printf("✅ Index up to date: %d questions (%d new)\n", ix.Len(), added)
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/X-for/ltgo/internal/client"
+	"github.com/X-for/ltgo/internal/config"
+	"github.com/X-for/ltgo/internal/harness"
+	"github.com/X-for/ltgo/internal/models"
+	"github.com/X-for/ltgo/internal/problem"
+	"github.com/spf13/cobra"
+)
+
+var testTimeout time.Duration
+
+var testCmd = &cobra.Command{
+	Use:   "test [file]",
+	Short: "Run a Go solution locally against the problem's examples",
+	Long: `Build a temporary Go program around the code between the @lc code markers,
+feed it the problem's sample inputs and compare the results with the expected
+outputs from the description. Nothing is sent to LeetCode.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		startTest(cmd.Context(), args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(testCmd)
+	addRefreshFlag(testCmd)
+	testCmd.Flags().DurationVar(&testTimeout, "timeout", harness.DefaultTimeout, "Time limit for running all cases")
+}
+
+func startTest(ctx context.Context, filePath string) {
+	// 1. 初始化 Client (题目详情一般已经在缓存里了)
+	cfg, err := config.Load()
+	if err != nil {
+		printConfigError(err)
+		return
+	}
+	c := client.New(cfg)
+	c.Refresh = refreshCache
+
+	// 2. 解析题解文件
+	sol, err := loadSolution(ctx, c, filePath)
+	if err != nil {
+		printError("", err)
+		return
+	}
+	if sol.Lang != "golang" {
+		fmt.Printf("Local testing only supports Go solutions (got %s). Use 'ltgo run' instead.\n", sol.Lang)
+		return
+	}
+
+	q, err := fetchQuestion(ctx, c, sol.Slug)
+	if err != nil {
+		printError("Failed to get question info", err)
+		return
+	}
+
+	// 3. 找入口函数和测试数据 (题目示例 + 之前保存的用例)
+	spec, err := buildSpec(q, sol.Code)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	saved, err := savedCases(sol, len(spec.Cases[0].Args))
+	if err != nil {
+		printError("", err)
+		return
+	}
+	spec.Cases = append(spec.Cases, saved...)
+
+	// 4. 本地编译运行
+	target := ""
+	if spec.Class != nil {
+		target = spec.Class.Name
+	} else {
+		target = spec.Func.Name
+	}
+	fmt.Printf("🧪 Testing %s locally (%d cases)...\n\n", target, len(spec.Cases))
+	report, err := harness.Run(ctx, *spec)
+	if err != nil {
+		var buildErr *harness.BuildError
+		if errors.As(err, &buildErr) {
+			fmt.Println("❌ Compile Error:")
+			fmt.Println(buildErr.Output)
+			return
+		}
+		if ctx.Err() != nil {
+			printError("", err)
+			return
+		}
+		fmt.Printf("❌ %v\n", err)
+		return
+	}
+
+	printReport(report)
+}
+
+// buildSpec 从题目信息里确定入口 (函数或设计类) 和测试用例
+func buildSpec(q *models.QuestionDetail, code string) (*harness.Spec, error) {
+	spec := &harness.Spec{Code: code, Timeout: testTimeout}
+
+	name := ""
+	meta, err := problem.ParseMeta(q.MetaData)
+	if err == nil {
+		if meta.Manual {
+			return nil, fmt.Errorf("this problem can't be tested locally, use 'ltgo run' instead")
+		}
+		name = meta.Name
+		if meta.Output.ParamIndex != nil {
+			spec.OutputParam = *meta.Output.ParamIndex
+		}
+	} else {
+		meta = nil
+	}
+
+	var names []string
+	if problem.IsDesign(meta, code) {
+		// 设计类: 每个用例是 操作名列表 + 参数列表 两行
+		class, err := problem.ParseGoClass(code)
+		if err != nil {
+			return nil, err
+		}
+		spec.Class = class
+		names = []string{"operations", "arguments"}
+	} else {
+		fn, err := problem.ParseGoFunc(code, name)
+		if err != nil {
+			return nil, err
+		}
+		spec.Func = fn
+		names = fn.ParamNames()
+	}
+
+	spec.Cases = problem.Cases(q, names)
+	if len(spec.Cases) == 0 {
+		return nil, fmt.Errorf("no example test cases found for this problem")
+	}
+	return spec, nil
+}
+
+// savedCases 题解旁边 .testcases 文件里的用例，参数个数必须是 arity
+func savedCases(sol *solution, arity int) ([]problem.Case, error) {
+	path := problem.TestcasePath(sol.Path)
+	cases, err := problem.LoadTestcases(path)
+	if err != nil {
+		return nil, err
+	}
+	for i, c := range cases {
+		if err := problem.CheckCase(c, arity); err != nil {
+			return nil, fmt.Errorf("%s: case %d: %w", path, i+1, err)
+		}
+	}
+	return cases, nil
+}
+
+// replaySaved 提交前在本地重放保存过的失败用例 (只支持 Go)
+// 有用例还没通过时返回错误；本地跑不了的情况只给提示，不拦截提交
+func replaySaved(ctx context.Context, sol *solution, q *models.QuestionDetail) error {
+	if sol.Lang != "golang" {
+		return nil
+	}
+	spec, err := buildSpec(q, sol.Code)
+	if err != nil {
+		return nil
+	}
+	saved, err := savedCases(sol, len(spec.Cases[0].Args))
+	if err != nil {
+		return err
+	}
+	spec.Cases = spec.Cases[:0]
+	for _, c := range saved {
+		if c.Expected != "" {
+			spec.Cases = append(spec.Cases, c)
+		}
+	}
+	if len(spec.Cases) == 0 {
+		return nil
+	}
+
+	fmt.Printf("🧪 Replaying %d saved cases locally...\n", len(spec.Cases))
+	report, err := harness.Run(ctx, *spec)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		fmt.Printf("⚠️  Skipping local check: %v\n", err)
+		return nil
+	}
+
+	failed := 0
+	for _, r := range report.Results {
+		if r.Passed() {
+			continue
+		}
+		failed++
+		fmt.Printf("  Input:    %s\n", strings.Join(r.Args, " "))
+		if r.Error != "" {
+			fmt.Printf("  Error:    %s\n", r.Error)
+		} else {
+			fmt.Printf("  Output:   %s\n", r.Output)
+		}
+		fmt.Printf("  Expected: %s\n", r.Expected)
+		fmt.Println("  ------------------------")
+	}
+	if failed > 0 {
+		return fmt.Errorf("❌ %d/%d saved cases still fail", failed, len(spec.Cases))
+	}
+	fmt.Printf("✅ All %d saved cases passed\n", len(spec.Cases))
+	return nil
+}
+
+func printReport(report *harness.Report) {
+	passed, checked := 0, 0
+	for i, r := range report.Results {
+		fmt.Printf("Case %d:\n", i+1)
+		fmt.Printf("  Input:    %s\n", strings.Join(r.Args, " "))
+		if r.Error != "" {
+			fmt.Printf("  Error:    %s\n", r.Error)
+		} else {
+			fmt.Printf("  Output:   %s\n", r.Output)
+		}
+		if r.Checked() {
+			checked++
+			fmt.Printf("  Expected: %s\n", r.Expected)
+			if r.Passed() {
+				passed++
+				fmt.Println("  Result:   ✅")
+			} else {
+				fmt.Println("  Result:   ❌")
+			}
+		}
+		fmt.Println("  ------------------------")
+	}
+
+	if report.Stdout != "" {
+		fmt.Println("Stdout:")
+		fmt.Println(strings.TrimRight(report.Stdout, "\n"))
+		fmt.Println()
+	}
+
+	switch {
+	case checked == 0:
+		fmt.Println("⚠️  No expected outputs found in the description, check the results manually.")
+	case passed == checked:
+		fmt.Printf("✅ All %d cases passed\n", checked)
+	default:
+		fmt.Printf("❌ %d/%d cases passed\n", passed, checked)
+	}
+}
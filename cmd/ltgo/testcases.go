@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/X-for/ltgo/internal/models"
+	"github.com/X-for/ltgo/internal/problem"
+)
+
+var (
+	testcaseArgs []string
+	testcaseFile string
+)
+
+// runCases 组装 ltgo run 要发送的用例:
+// 指定了 --testcase / --testcase-file 时用指定的，否则用题目自带的示例；
+// 题解旁边的 .testcases 文件总是追加在后面
+func runCases(sol *solution, q *models.QuestionDetail) ([]problem.Case, error) {
+	arity := 0
+	if meta, err := problem.ParseMeta(q.MetaData); err == nil {
+		arity = meta.Arity()
+	}
+
+	var cases []problem.Case
+	for _, arg := range testcaseArgs {
+		cases = append(cases, problem.ParseTestcaseArg(arg))
+	}
+	if testcaseFile != "" {
+		fromFile, err := problem.LoadTestcases(testcaseFile)
+		if err != nil {
+			return nil, err
+		}
+		if len(fromFile) == 0 {
+			return nil, fmt.Errorf("no test cases found in %s", testcaseFile)
+		}
+		cases = append(cases, fromFile...)
+	}
+	if len(cases) == 0 {
+		cases = exampleCases(q, arity)
+	}
+
+	saved, err := problem.LoadTestcases(problem.TestcasePath(sol.Path))
+	if err != nil {
+		return nil, err
+	}
+	cases = append(cases, saved...)
+
+	for i, c := range cases {
+		if err := problem.CheckCase(c, arity); err != nil {
+			return nil, fmt.Errorf("invalid test case %d: %w", i+1, err)
+		}
+	}
+	return cases, nil
+}
+
+// exampleCases 题目自带的示例输入 (exampleTestcases 没有时用 sampleTestCase)
+func exampleCases(q *models.QuestionDetail, arity int) []problem.Case {
+	for _, raw := range []string{q.ExampleTestcases, q.SampleTestCase} {
+		if raw == "" {
+			continue
+		}
+		inputs, ok := problem.SplitTestcases(raw, arity)
+		if !ok {
+			// 不知道参数个数，整体当成一个用例
+			return []problem.Case{problem.ParseTestcaseArg(raw)}
+		}
+		cases := make([]problem.Case, len(inputs))
+		for i, args := range inputs {
+			cases[i].Args = args
+		}
+		return cases
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/X-for/ltgo/internal/config"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var unlockTTL time.Duration
+
+var unlockCmd = &cobra.Command{
+	Use:   "unlock",
+	Short: "Unlock encrypted credentials for this session",
+	Long: `Ask for the passphrase once and keep the credentials unlocked for a while,
+so other commands don't ask again. The key is kept in $XDG_RUNTIME_DIR, which
+is cleared when you log out. It must be owned by you with mode 0700; otherwise
+nothing is cached.
+
+Without 'ltgo unlock', commands ask for the passphrase every time the
+credentials are locked; in scripts, set LTGO_PASSPHRASE instead.`,
+	Example: `  ltgo unlock
+  ltgo unlock --for 30m`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runUnlock()
+	},
+}
+
+var lockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Forget the unlocked credentials",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := config.Lock(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Println("🔒 Locked.")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(unlockCmd, lockCmd)
+	unlockCmd.Flags().DurationVar(&unlockTTL, "for", config.DefaultUnlockTTL, "How long to stay unlocked")
+
+	// 加密的 Cookie 需要口令时从终端读
+	config.Passphrase = readPassphrase
+}
+
+func runUnlock() {
+	encrypted, err := config.Encrypted()
+	if err != nil {
+		printConfigError(err)
+		return
+	}
+	if !encrypted {
+		fmt.Println("Credentials are not encrypted, nothing to unlock (see 'ltgo config encrypt').")
+		return
+	}
+
+	pass, err := readPassphrase("Passphrase: ")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if err := config.Unlock(pass, unlockTTL); err != nil {
+		printConfigError(err)
+		return
+	}
+	fmt.Printf("🔓 Unlocked for %s.\n", unlockTTL)
+}
+
+// readPassphrase 从终端读口令 (不回显)，不是终端时 (管道、CI) 返回 config.ErrLocked
+func readPassphrase(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", config.ErrLocked
+	}
+	fmt.Print(prompt)
+	pass, err := term.ReadPassword(fd)
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+	if len(pass) == 0 {
+		return "", errors.New("empty passphrase")
+	}
+	return string(pass), nil
+}
@@ -4,16 +4,18 @@ go 1.25.7
 
 require (
 	github.com/jaytaylor/html2text v0.0.0-20230321000545-74c2419ad056
+	github.com/mattn/go-runewidth v0.0.19
 	github.com/spf13/cobra v1.10.2
+	golang.org/x/net v0.50.0
+	golang.org/x/term v0.40.0
 )
 
 require (
 	github.com/clipperhouse/stringish v0.1.1 // indirect
 	github.com/clipperhouse/uax29/v2 v2.3.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/mattn/go-runewidth v0.0.19 // indirect
 	github.com/olekukonko/tablewriter v0.0.5 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
 	github.com/ssor/bom v0.0.0-20170718123548-6386211fdfcf // indirect
-	golang.org/x/net v0.50.0 // indirect
+	golang.org/x/sys v0.41.0 // indirect
 )
@@ -0,0 +1,114 @@
+// Package browser 从本地浏览器的 Cookie 数据库里读 LeetCode 的登录 Cookie (仅 Linux)
+package browser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Supported 支持的浏览器
+var Supported = []string{"firefox", "chromium", "chrome"}
+
+// Cookies 读取 browser 里 host (如 "leetcode.com") 及其子域名下的所有 Cookie
+// profile 为空时自动找默认的配置目录，也可以直接给 Cookie 数据库文件的路径
+func Cookies(browser, host, profile string) ([]*http.Cookie, error) {
+	switch strings.ToLower(browser) {
+	case "firefox":
+		return firefoxCookies(host, profile)
+	case "chromium":
+		return chromiumCookies(host, profile, "chromium")
+	case "chrome", "google-chrome":
+		return chromiumCookies(host, profile, "google-chrome")
+	}
+	return nil, fmt.Errorf("unsupported browser '%s' (supported: %s)", browser, strings.Join(Supported, ", "))
+}
+
+// CookieString 拼成请求头里的格式 "a=1; b=2"
+func CookieString(cookies []*http.Cookie) string {
+	parts := make([]string, len(cookies))
+	for i, c := range cookies {
+		parts[i] = c.Name + "=" + c.Value
+	}
+	return strings.Join(parts, "; ")
+}
+
+// query 用 sqlite3 命令行执行查询，返回按行、按 \t 切好的结果
+// 浏览器运行时数据库是锁着的，所以先拷贝一份 (连同 -wal 文件) 到临时目录再读
+func query(db, sql string) ([][]string, error) {
+	sqlite, err := exec.LookPath("sqlite3")
+	if err != nil {
+		return nil, errors.New("reading browser cookies needs the 'sqlite3' command (e.g. apt install sqlite3)")
+	}
+
+	dir, err := os.MkdirTemp("", "ltgo-cookies-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	copyDB := filepath.Join(dir, "cookies.sqlite")
+	if err := copyFile(db, copyDB); err != nil {
+		return nil, fmt.Errorf("failed to read cookie database: %w", err)
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if _, err := os.Stat(db + suffix); err == nil {
+			_ = copyFile(db+suffix, copyDB+suffix)
+		}
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(sqlite, "-readonly", "-noheader", "-separator", "\t", copyDB, sql)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("sqlite3: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var rows [][]string
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		if line != "" {
+			rows = append(rows, strings.Split(line, "\t"))
+		}
+	}
+	return rows, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// hostFilter SQL 条件: host 本身、.host 和所有子域名
+func hostFilter(column, host string) string {
+	host = strings.ReplaceAll(host, "'", "")
+	return fmt.Sprintf("(%[1]s = '%[2]s' OR %[1]s = '.%[2]s' OR %[1]s LIKE '%%.%[2]s')", column, host)
+}
+
+// firstExisting 返回第一个存在的路径
+func firstExisting(paths ...string) (string, bool) {
+	for _, p := range paths {
+		if _, err := os.Stat(p); err == nil {
+			return p, true
+		}
+	}
+	return "", false
+}
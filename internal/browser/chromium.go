@@ -0,0 +1,193 @@
+package browser
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// chromiumDirs Chromium 系浏览器配置目录可能的位置 (普通安装 / snap / flatpak)
+func chromiumDirs(app string) []string {
+	home, _ := os.UserHomeDir()
+	dirs := []string{filepath.Join(home, ".config", app)}
+	switch app {
+	case "chromium":
+		dirs = append(dirs,
+			filepath.Join(home, "snap", "chromium", "common", "chromium"),
+			filepath.Join(home, ".var", "app", "org.chromium.Chromium", "config", "chromium"))
+	case "google-chrome":
+		dirs = append(dirs, filepath.Join(home, ".var", "app", "com.google.Chrome", "config", "google-chrome"))
+	}
+	return dirs
+}
+
+func chromiumCookies(host, profile, app string) ([]*http.Cookie, error) {
+	db, err := chromiumDB(profile, app)
+	if err != nil {
+		return nil, err
+	}
+
+	// 数据库版本 >= 24 时，解密后的值前面多了 32 字节的 sha256(host)
+	version := 0
+	if rows, err := query(db, "SELECT value FROM meta WHERE key = 'version';"); err == nil && len(rows) > 0 {
+		version, _ = strconv.Atoi(rows[0][0])
+	}
+
+	sql := fmt.Sprintf("SELECT name, value, hex(encrypted_value) FROM cookies WHERE %s ORDER BY length(host_key);", hostFilter("host_key", host))
+	rows, err := query(db, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	var cookies []*http.Cookie
+	seen := map[string]bool{}
+	for _, row := range rows {
+		if len(row) < 3 || seen[row[0]] {
+			continue
+		}
+		value := row[1]
+		if value == "" && row[2] != "" {
+			encrypted, err := hex.DecodeString(row[2])
+			if err != nil {
+				return nil, err
+			}
+			if value, err = chromiumDecrypt(encrypted, app, version >= 24); err != nil {
+				return nil, fmt.Errorf("cookie %s: %w", row[0], err)
+			}
+		}
+		seen[row[0]] = true
+		cookies = append(cookies, &http.Cookie{Name: row[0], Value: value})
+	}
+	return cookies, nil
+}
+
+// chromiumDB 找到 Cookies 数据库：profile 可以是数据库文件、配置目录 (如 ~/.config/chromium/Profile 1)，或者留空用 Default
+func chromiumDB(profile, app string) (string, error) {
+	var candidates []string
+	if profile != "" {
+		if info, err := os.Stat(profile); err == nil && !info.IsDir() {
+			return profile, nil
+		}
+		candidates = []string{filepath.Join(profile, "Network", "Cookies"), filepath.Join(profile, "Cookies")}
+	} else {
+		for _, dir := range chromiumDirs(app) {
+			candidates = append(candidates,
+				filepath.Join(dir, "Default", "Network", "Cookies"),
+				filepath.Join(dir, "Default", "Cookies"))
+		}
+	}
+	if db, ok := firstExisting(candidates...); ok {
+		return db, nil
+	}
+	if profile != "" {
+		return "", fmt.Errorf("%s cookie database not found in %s", app, profile)
+	}
+	return "", fmt.Errorf("no %s profile found (looked in ~/.config/%s), use --browser-profile", app, app)
+}
+
+// chromiumDecrypt Linux 上 Chromium 的 Cookie 加密:
+// v10 用固定口令 "peanuts"；v11 用桌面密钥环 (Secret Service) 里保存的口令。
+// 口令经 PBKDF2-SHA1 (salt "saltysalt", 1 轮) 得到 AES-128 密钥，CBC 模式，IV 是 16 个空格
+func chromiumDecrypt(encrypted []byte, app string, hostPrefix bool) (string, error) {
+	if len(encrypted) < 3 {
+		return "", errors.New("encrypted value too short")
+	}
+	prefix, data := string(encrypted[:3]), encrypted[3:]
+
+	var passwords []string
+	switch prefix {
+	case "v10":
+		passwords = []string{"peanuts"}
+	case "v11":
+		if pw := keyringPassword(app); pw != "" {
+			passwords = append(passwords, pw)
+		}
+		// 没有密钥环时 Chromium 用的是空口令
+		passwords = append(passwords, "")
+	default:
+		return "", fmt.Errorf("unsupported encryption version %q", prefix)
+	}
+
+	for _, pw := range passwords {
+		plain, err := aesCBCDecrypt(data, pw)
+		if err != nil {
+			continue
+		}
+		if hostPrefix && len(plain) >= 32 {
+			plain = plain[32:]
+		}
+		if printable(plain) {
+			return string(plain), nil
+		}
+	}
+	if prefix == "v11" {
+		return "", errors.New("encrypted with the desktop keyring and the key could not be read (is 'secret-tool' installed and the keyring unlocked?)")
+	}
+	return "", errors.New("failed to decrypt")
+}
+
+func aesCBCDecrypt(data []byte, password string) ([]byte, error) {
+	key, err := pbkdf2.Key(sha1.New, password, []byte("saltysalt"), 1, 16)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, errors.New("invalid ciphertext length")
+	}
+	plain := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, bytes.Repeat([]byte(" "), aes.BlockSize)).CryptBlocks(plain, data)
+
+	// PKCS#7
+	pad := int(plain[len(plain)-1])
+	if pad == 0 || pad > aes.BlockSize || pad > len(plain) {
+		return nil, errors.New("bad padding")
+	}
+	for _, b := range plain[len(plain)-pad:] {
+		if int(b) != pad {
+			return nil, errors.New("bad padding")
+		}
+	}
+	return plain[:len(plain)-pad], nil
+}
+
+// keyringPassword 用 secret-tool 读 Chromium 存在密钥环里的口令，读不到返回空
+func keyringPassword(app string) string {
+	tool, err := exec.LookPath("secret-tool")
+	if err != nil {
+		return ""
+	}
+	name := "chromium"
+	if app == "google-chrome" {
+		name = "chrome"
+	}
+	out, err := exec.Command(tool, "lookup", "application", name).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// printable Cookie 值只会是可见 ASCII，解出乱码说明密钥不对
+func printable(b []byte) bool {
+	for _, c := range b {
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package browser
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// firefoxRoots Firefox 配置目录可能的位置 (普通安装 / snap / flatpak)
+func firefoxRoots() []string {
+	home, _ := os.UserHomeDir()
+	return []string{
+		filepath.Join(home, ".mozilla", "firefox"),
+		filepath.Join(home, "snap", "firefox", "common", ".mozilla", "firefox"),
+		filepath.Join(home, ".var", "app", "org.mozilla.firefox", ".mozilla", "firefox"),
+	}
+}
+
+func firefoxCookies(host, profile string) ([]*http.Cookie, error) {
+	db, err := firefoxDB(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf("SELECT name, value FROM moz_cookies WHERE %s ORDER BY length(host);", hostFilter("host", host))
+	rows, err := query(db, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	var cookies []*http.Cookie
+	seen := map[string]bool{}
+	for _, row := range rows {
+		if len(row) < 2 || seen[row[0]] {
+			continue
+		}
+		seen[row[0]] = true
+		cookies = append(cookies, &http.Cookie{Name: row[0], Value: row[1]})
+	}
+	return cookies, nil
+}
+
+// firefoxDB 找到 cookies.sqlite：profile 可以是数据库文件、配置目录，或者留空用默认配置
+func firefoxDB(profile string) (string, error) {
+	if profile != "" {
+		if info, err := os.Stat(profile); err == nil && info.IsDir() {
+			profile = filepath.Join(profile, "cookies.sqlite")
+		}
+		if _, err := os.Stat(profile); err != nil {
+			return "", fmt.Errorf("firefox cookie database not found: %s", profile)
+		}
+		return profile, nil
+	}
+
+	for _, root := range firefoxRoots() {
+		dir, ok := firefoxDefaultProfile(root)
+		if !ok {
+			continue
+		}
+		if db, ok := firstExisting(filepath.Join(dir, "cookies.sqlite")); ok {
+			return db, nil
+		}
+	}
+	return "", fmt.Errorf("no Firefox profile found (looked in ~/.mozilla/firefox), use --browser-profile")
+}
+
+// firefoxDefaultProfile 解析 profiles.ini 找默认配置
+// 优先 [Install...] 里的 Default (新版 Firefox 真正在用的)，其次 Default=1 的 [Profile]，最后第一个 [Profile]
+func firefoxDefaultProfile(root string) (string, bool) {
+	f, err := os.Open(filepath.Join(root, "profiles.ini"))
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	type section struct {
+		name string
+		kv   map[string]string
+	}
+	var sections []*section
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			sections = append(sections, &section{name: line[1 : len(line)-1], kv: map[string]string{}})
+		case len(sections) > 0:
+			if k, v, ok := strings.Cut(line, "="); ok {
+				sections[len(sections)-1].kv[k] = v
+			}
+		}
+	}
+
+	resolve := func(path string, relative bool) string {
+		if relative {
+			return filepath.Join(root, path)
+		}
+		return path
+	}
+
+	for _, s := range sections {
+		if strings.HasPrefix(s.name, "Install") && s.kv["Default"] != "" {
+			return resolve(s.kv["Default"], !filepath.IsAbs(s.kv["Default"])), true
+		}
+	}
+	var first string
+	for _, s := range sections {
+		if !strings.HasPrefix(s.name, "Profile") || s.kv["Path"] == "" {
+			continue
+		}
+		path := resolve(s.kv["Path"], s.kv["IsRelative"] != "0")
+		if s.kv["Default"] == "1" {
+			return path, true
+		}
+		if first == "" {
+			first = path
+		}
+	}
+	return first, first != ""
+}
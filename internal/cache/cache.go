@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/X-for/ltgo/internal/config"
+	"github.com/X-for/ltgo/internal/models"
+)
+
+// DefaultTTL 题目详情缓存的默认有效期
+// 题面和代码模板几乎不会变，一周足够了
+const DefaultTTL = 7 * 24 * time.Hour
+
+// formatVersion 缓存格式版本，QuestionDetail 新增字段时加一，旧缓存自动失效
+const formatVersion = 2
+
+// entry 缓存文件的内容
+type entry struct {
+	Version  int                    `json:"version"`
+	CachedAt time.Time              `json:"cachedAt"`
+	Question *models.QuestionDetail `json:"question"`
+}
+
+// Item 缓存条目的概要 (给 ltgo cache 展示用)
+type Item struct {
+	Slug     string
+	Title    string
+	CachedAt time.Time
+	Size     int64
+	Expired  bool
+}
+
+// Store 题目详情的磁盘缓存，按站点分目录，一个 slug 一个文件
+// ~/.ltgo/cache/<site>/<slug>.json
+type Store struct {
+	dir string
+	TTL time.Duration
+}
+
+// Open 打开某个站点的缓存目录 (目录在第一次写入时才创建)
+func Open(site string, ttl time.Duration) (*Store, error) {
+	base, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Store{dir: filepath.Join(base, "cache", site), TTL: ttl}, nil
+}
+
+// Dir 缓存目录
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+func (s *Store) path(slug string) string {
+	return filepath.Join(s.dir, slug+".json")
+}
+
+// Get 读取未过期的缓存，过期或读不到都当作未命中
+func (s *Store) Get(slug string) (*models.QuestionDetail, bool) {
+	e, err := s.read(slug)
+	if err != nil || e.Question == nil {
+		return nil, false
+	}
+	if e.Version != formatVersion || time.Since(e.CachedAt) > s.TTL {
+		return nil, false
+	}
+	return e.Question, true
+}
+
+// Put 写入缓存
+func (s *Store) Put(q *models.QuestionDetail) error {
+	if q.TitleSlug == "" {
+		return errors.New("cannot cache question without slug")
+	}
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry{Version: formatVersion, CachedAt: time.Now(), Question: q})
+	if err != nil {
+		return err
+	}
+
+	// 先写临时文件再 rename，并发的 ltgo 进程不会读到半个文件
+	tmp := s.path(q.TitleSlug) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path(q.TitleSlug))
+}
+
+// List 列出所有缓存条目，按 slug 排序
+func (s *Store) List() ([]Item, error) {
+	files, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var items []Item
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		slug := strings.TrimSuffix(f.Name(), ".json")
+		info, err := f.Info()
+		if err != nil {
+			continue
+		}
+
+		item := Item{Slug: slug, Size: info.Size()}
+		if e, err := s.read(slug); err == nil && e.Question != nil {
+			item.Title = e.Question.Title
+			item.CachedAt = e.CachedAt
+			item.Expired = e.Version != formatVersion || time.Since(e.CachedAt) > s.TTL
+		} else {
+			// 坏掉的文件也列出来，方便清理
+			item.Expired = true
+		}
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].Slug < items[j].Slug })
+	return items, nil
+}
+
+// Remove 删除某道题的缓存
+func (s *Store) Remove(slug string) error {
+	err := os.Remove(s.path(slug))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// Clear 清空整个站点的缓存，返回删除的条目数
+func (s *Store) Clear() (int, error) {
+	items, err := s.List()
+	if err != nil {
+		return 0, err
+	}
+	for _, it := range items {
+		if err := s.Remove(it.Slug); err != nil {
+			return 0, err
+		}
+	}
+	return len(items), nil
+}
+
+func (s *Store) read(slug string) (*entry, error) {
+	data, err := os.ReadFile(s.path(slug))
+	if err != nil {
+		return nil, err
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
@@ -2,12 +2,17 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/cookiejar"
 	"time"
 
+	"github.com/X-for/ltgo/internal/cache"
 	"github.com/X-for/ltgo/internal/config"
+	"github.com/X-for/ltgo/internal/index"
 )
 
 type Client struct {
@@ -15,6 +20,26 @@ type Client struct {
 	cfg      *config.Config
 	BaseURL  string
 	EndPoint string
+
+	// Refresh 为 true 时跳过题目详情缓存，强制从服务端拉取 (结果仍会写回缓存)
+	Refresh bool
+
+	// PersistCookies 为 true 时服务端轮换的 Cookie 会写回配置文件 (ltgo init 验证阶段关掉)
+	PersistCookies bool
+
+	// SyncProgress ResolveQuestion 需要先同步索引时调用，开始时 done 为 0，可以为 nil
+	// 冷启动要拉几千道题，调用方可以借此打印进度，免得看起来像卡住了
+	SyncProgress func(done, total int)
+
+	// PartialErrors 上一次 GraphQL 响应里和 data 一起返回的 errors (部分字段失败，比如某个字段要登录)
+	// 这种情况 GraphQL 不报错，调用方需要时自己看
+	PartialErrors string
+
+	retries int            // 429 / 5xx 时的重试次数，见 RetryPolicy
+	jar     *cookiejar.Jar // 第一次请求时由 cfg.Cookie 初始化，见 initJar
+
+	index *index.Index // 本地题目索引，见 Index()
+	cache *cache.Store // 题目详情缓存，打不开时为 nil (不影响正常使用)
 }
 
 func New(cfg *config.Config) *Client {
@@ -24,6 +49,13 @@ func New(cfg *config.Config) *Client {
 		baseURL = "https://leetcode.cn"
 		endpoint = "https://leetcode.cn/graphql/"
 	}
+	ttl, _ := time.ParseDuration(cfg.CacheTTL) // 解析失败就用默认值
+	store, _ := cache.Open(cfg.Site, ttl)
+	retries := DefaultRetries
+	if cfg.Retries != nil && *cfg.Retries >= 0 {
+		retries = *cfg.Retries
+	}
+
 	return &Client{
 		http: &http.Client{
 			Timeout: 10 * time.Second,
@@ -31,49 +63,115 @@ func New(cfg *config.Config) *Client {
 		cfg:      cfg,
 		BaseURL:  baseURL,
 		EndPoint: endpoint,
+		cache:    store,
+		retries:  retries,
+
+		PersistCookies: true,
+	}
+}
+
+// Cache 返回题目详情缓存 (可能为 nil)
+func (c *Client) Cache() *cache.Store {
+	return c.cache
+}
+
+// Get 查询类的 GET 请求，失败时按 readPolicy 重试
+func (c *Client) Get(ctx context.Context, path string) ([]byte, error) {
+	return c.do(ctx, "GET", path, nil, c.readPolicy())
+}
+
+// Post 有副作用的 POST 请求 (运行 / 提交代码)，只在被限流时重试
+func (c *Client) Post(ctx context.Context, path string, body []byte) ([]byte, error) {
+	return c.do(ctx, "POST", path, body, c.submitPolicy())
+}
+
+// do 发送请求，按 policy 处理 429 / 5xx / 网络错误的重试
+func (c *Client) do(ctx context.Context, method, path string, body []byte, policy RetryPolicy) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		data, err := c.doOnce(ctx, method, path, body)
+		if err == nil {
+			return data, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if !policy.retryable(err) {
+			return nil, err
+		}
+		if attempt >= policy.Retries {
+			if attempt == 0 {
+				return nil, err
+			}
+			return nil, &RetryError{Attempts: attempt + 1, Err: err}
+		}
+		d, ok := policy.delay(attempt, err)
+		if !ok {
+			// Retry-After 太久了，不干等，错误里带着要等多久
+			return nil, err
+		}
+		if err := wait(ctx, d); err != nil {
+			return nil, err
+		}
 	}
 }
 
-func (c *Client) Get(path string) ([]byte, error) {
+func (c *Client) doOnce(ctx context.Context, method, path string, body []byte) ([]byte, error) {
 	url := c.BaseURL + path
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.initJar()
 	c.enhanceRequest(req)
 	resp, err := c.http.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
-	return io.ReadAll(resp.Body)
-}
-
-func (c *Client) Post(path string, body []byte) ([]byte, error) {
-	url := c.BaseURL + path
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
+	c.saveRotatedCookies()
+	data, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	c.enhanceRequest(req)
-	resp, err := c.http.Do(req)
-	if err != nil {
+	if err := statusError(resp, data); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	return io.ReadAll(resp.Body)
+	if isHTML(resp, data) {
+		return nil, &APIError{Kind: ErrNotSignedIn, Message: "got a web page instead of JSON, the cookie has probably expired"}
+	}
+	return data, nil
+}
+
+// pollInterval 轮询判题结果的间隔
+const pollInterval = time.Second
+
+// wait 等待 d，ctx 取消时立刻返回 ctx.Err()
+func wait(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
 }
 
 func (c *Client) enhanceRequest(req *http.Request) {
-	if c.cfg.Cookie != "" {
-		req.Header.Set("Cookie", c.cfg.Cookie)
+	// Cookie 头由 cookie jar 负责
+	if token := c.csrfToken(); token != "" {
+		req.Header.Set("x-csrftoken", token)
 	}
-	req.Header.Set("Referer", c.BaseURL)
+	req.Header.Set("Referer", c.referer(req))
 	req.Header.Set("Origin", c.BaseURL)
-	req.Header.Set("User-agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/144.0.0.0 Safari/537.36")
+	req.Header.Set("User-agent", userAgent)
 }
 
+const userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/144.0.0.0 Safari/537.36"
+
 // 在 import 里添加 "encoding/json"
 
 // GraphQLPayload 发送给服务器的请求体结构
@@ -83,7 +181,7 @@ type GraphQLPayload struct {
 }
 
 // GraphQL 发送 GraphQL 请求并解析结果到 target
-func (c *Client) GraphQL(query string, variables interface{}, target interface{}) error {
+func (c *Client) GraphQL(ctx context.Context, query string, variables interface{}, target interface{}) error {
 	// 1. 构造请求体
 	payload := GraphQLPayload{
 		Query:     query,
@@ -97,16 +195,27 @@ func (c *Client) GraphQL(query string, variables interface{}, target interface{}
 
 	// 2. 发送 POST 请求 (注意：GraphQL 的 Endpoint 通常是 /graphql)
 	// 如果是 .cn 站点，可能是 /graphql/
-	respBody, err := c.Post("/graphql/", body)
+	// GraphQL 这里只有查询，可以放心重试
+	respBody, err := c.do(ctx, "POST", "/graphql/", body, c.readPolicy())
 	if err != nil {
 		return err
 	}
 
 	//fmt.Println("DEBUG:", string(respBody))
 
-	// 3. 解析响应
+	// 3. 服务端报错时 data 是 null，这时才算失败；
+	// 有 data 的 errors 只是部分字段出错 (比如需要登录的字段)，照常解析
+	c.PartialErrors = ""
+	if msg := errorMessage(respBody); msg != "" {
+		if !hasData(respBody) {
+			return newAPIError(msg, nil)
+		}
+		c.PartialErrors = msg
+	}
+
+	// 4. 解析响应
 	if err := json.Unmarshal(respBody, target); err != nil {
-		return err
+		return fmt.Errorf("unexpected GraphQL response: %w", err)
 	}
 
 	return nil
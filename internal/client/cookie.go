@@ -0,0 +1,146 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// CookieValue 从浏览器里复制的 Cookie 字符串 ("a=1; b=2") 里取出 name 的值
+func CookieValue(cookie, name string) string {
+	for _, part := range strings.Split(cookie, ";") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if ok && k == name {
+			return strings.Trim(v, `"`)
+		}
+	}
+	return ""
+}
+
+// csrfToken 当前的 csrftoken (可能已经被服务端轮换过)，运行 / 提交代码时要放到 x-csrftoken 头里
+func (c *Client) csrfToken() string {
+	return CookieValue(c.Cookie(), "csrftoken")
+}
+
+// Cookie 当前的 Cookie 字符串：配置里的 Cookie 加上服务端通过 Set-Cookie 更新过的值
+func (c *Client) Cookie() string {
+	if c.jar == nil {
+		return c.cfg.Cookie
+	}
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return c.cfg.Cookie
+	}
+	// 只关心登录相关的和配置里本来就有的，其他 (统计、CDN) 的 Cookie 不往配置里写
+	var updates []*http.Cookie
+	for _, ck := range c.jar.Cookies(u) {
+		if ck.Name == "LEETCODE_SESSION" || ck.Name == "csrftoken" || CookieValue(c.cfg.Cookie, ck.Name) != "" {
+			updates = append(updates, ck)
+		}
+	}
+	return MergeCookie(c.cfg.Cookie, updates)
+}
+
+// initJar 第一次请求前把配置里的 Cookie 放进 cookie jar
+// 之后由 jar 负责发送 Cookie 和接收 Set-Cookie
+// 服务端轮换 Cookie 时带 Domain=.leetcode.com，这里也按整个域名存，
+// 否则 jar 里会有两个同名的 Cookie (一个只属于 host)，旧的也会一起发出去
+func (c *Client) initJar() {
+	if c.jar != nil {
+		return
+	}
+	jar, _ := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return
+	}
+	domain := cookieDomain(u.Hostname())
+	var cookies []*http.Cookie
+	for _, part := range strings.Split(c.cfg.Cookie, ";") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if ok && k != "" {
+			cookies = append(cookies, &http.Cookie{Name: k, Value: strings.Trim(v, `"`), Path: "/", Domain: domain})
+		}
+	}
+	jar.SetCookies(u, cookies)
+	c.jar = jar
+	c.http.Jar = jar
+}
+
+// cookieDomain host 的可注册域名 (www.leetcode.com -> leetcode.com)，IP 地址原样返回
+func cookieDomain(host string) string {
+	if net.ParseIP(host) != nil {
+		return host
+	}
+	if domain, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		return domain
+	}
+	return host
+}
+
+// saveRotatedCookies 服务端轮换了 LEETCODE_SESSION / csrftoken 时写回配置文件，
+// 这样只要一直在用 ltgo，登录状态就不会过期
+func (c *Client) saveRotatedCookies() {
+	cookie := c.Cookie()
+	if cookie == c.cfg.Cookie {
+		return
+	}
+	c.cfg.Cookie = cookie
+	if c.PersistCookies {
+		// 写失败不影响本次请求，下次还会再试
+		_ = c.cfg.SaveCookie(cookie)
+	}
+}
+
+// MergeCookie 用 updates 更新 Cookie 字符串里的值，保持原来的顺序，新出现的追加在末尾
+func MergeCookie(cookie string, updates []*http.Cookie) string {
+	values := map[string]string{}
+	for _, u := range updates {
+		values[u.Name] = u.Value
+	}
+
+	var parts []string
+	seen := map[string]bool{}
+	for _, part := range strings.Split(cookie, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		k, _, _ := strings.Cut(part, "=")
+		if v, ok := values[k]; ok && !seen[k] {
+			part = k + "=" + v
+		}
+		seen[k] = true
+		parts = append(parts, part)
+	}
+	for _, u := range updates {
+		if !seen[u.Name] {
+			seen[u.Name] = true
+			parts = append(parts, u.Name+"="+u.Value)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// requireCSRF 有副作用的请求发出去之前先确认有 csrftoken，没有的话服务端只会回 403
+func (c *Client) requireCSRF() error {
+	if c.csrfToken() == "" {
+		return &APIError{Kind: ErrNoCSRFToken, Message: "the saved cookie has no csrftoken"}
+	}
+	return nil
+}
+
+// referer 请求题目相关接口时带上题目页面作为 Referer，和浏览器里的行为一致
+// /problems/two-sum/submit/ -> https://leetcode.com/problems/two-sum/
+func (c *Client) referer(req *http.Request) string {
+	if rest, ok := strings.CutPrefix(req.URL.Path, "/problems/"); ok {
+		if slug, _, _ := strings.Cut(rest, "/"); slug != "" {
+			return c.BaseURL + "/problems/" + slug + "/"
+		}
+	}
+	return c.BaseURL
+}
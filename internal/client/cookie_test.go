@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/X-for/ltgo/internal/config"
+)
+
+// 服务端用 Domain=.leetcode.test 轮换 csrftoken 之后，只能发新的那一个
+func TestRotatedDomainCookieReplacesSeeded(t *testing.T) {
+	var cookies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookies = append(cookies, r.Header.Get("Cookie"))
+		if len(cookies) == 1 {
+			http.SetCookie(w, &http.Cookie{Name: "csrftoken", Value: "new", Domain: ".leetcode.test", Path: "/"})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	// 所有请求都连到测试服务器，URL 里用的是真正的域名
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, srv.Listener.Addr().String())
+		},
+	}
+	c := &Client{
+		http:    &http.Client{Transport: transport},
+		cfg:     &config.Config{Cookie: "LEETCODE_SESSION=s; csrftoken=old"},
+		BaseURL: "http://www.leetcode.test",
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Get(context.Background(), "/"); err != nil {
+			t.Fatalf("request %d: %v", i+1, err)
+		}
+	}
+	if n := strings.Count(cookies[1], "csrftoken="); n != 1 {
+		t.Errorf("second request sent %d csrftoken cookies: %q", n, cookies[1])
+	}
+	if !strings.Contains(cookies[1], "csrftoken=new") {
+		t.Errorf("second request did not send the rotated csrftoken: %q", cookies[1])
+	}
+	if got := c.csrfToken(); got != "new" {
+		t.Errorf("csrfToken = %q, want new", got)
+	}
+}
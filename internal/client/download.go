@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Download 下载题面里引用的图片等静态文件
+// 图片在 CDN 上，不需要登录，所以不带 Cookie；相对路径按当前站点补全
+func (c *Client) Download(ctx context.Context, url string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(url, "//"):
+		url = "https:" + url
+	case strings.HasPrefix(url, "/"):
+		url = c.BaseURL + url
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-agent", userAgent)
+	// c.http 的 Jar 里有登录 Cookie，复制一份去掉 Jar 再发
+	plain := *c.http
+	plain.Jar = nil
+	resp, err := plain.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := statusError(resp, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// 图片请求不能把登录 Cookie 带出去，即使 jar 里已经有了
+func TestDownloadSendsNoCookies(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("Cookie"))
+		w.Write([]byte("png"))
+	}))
+	defer srv.Close()
+
+	jar, _ := cookiejar.New(nil)
+	u, _ := url.Parse(srv.URL)
+	jar.SetCookies(u, []*http.Cookie{{Name: "LEETCODE_SESSION", Value: "secret"}})
+	c := &Client{http: &http.Client{Jar: jar}, BaseURL: srv.URL}
+
+	data, err := c.Download(context.Background(), "/static/a.png")
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if string(data) != "png" {
+		t.Errorf("Download = %q", data)
+	}
+	if len(got) != 1 || got[0] != "" {
+		t.Errorf("Cookie headers = %q, want none", got)
+	}
+	if c.http.Jar != jar {
+		t.Error("Download changed the client's jar")
+	}
+}
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// 可以用 errors.Is 判断的错误类型，具体的服务端消息在 APIError 里
+var (
+	ErrNotSignedIn     = errors.New("not signed in")
+	ErrRateLimited     = errors.New("rate limited")
+	ErrPremiumRequired = errors.New("premium subscription required")
+	ErrNotFound        = errors.New("not found")
+	ErrServer          = errors.New("server error")
+	ErrNoCSRFToken     = errors.New("missing csrftoken")
+)
+
+// APIError LeetCode 返回的错误 (GraphQL errors、接口里的 error 字段、登录页等)
+type APIError struct {
+	Kind    error  // 上面的 Err* 之一，认不出来时为 nil
+	Message string // 服务端给的原始消息
+}
+
+func (e *APIError) Error() string {
+	switch {
+	case e.Kind == nil:
+		return e.Message
+	case e.Message == "":
+		return e.Kind.Error()
+	}
+	return e.Kind.Error() + ": " + e.Message
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Kind
+}
+
+// kindForStatus HTTP 状态码对应的错误类型
+func kindForStatus(code int) error {
+	switch {
+	case code == http.StatusUnauthorized, code == http.StatusForbidden:
+		return ErrNotSignedIn
+	case code == http.StatusNotFound:
+		return ErrNotFound
+	case code == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case code >= 500:
+		return ErrServer
+	}
+	return nil
+}
+
+// kindForMessage 状态码说明不了问题时 (200 / 400)，按消息内容猜错误类型
+func kindForMessage(msg string) error {
+	m := strings.ToLower(msg)
+	switch {
+	case containsAny(m, "not authenticated", "not logged in", "login required", "sign in", "log in", "authentication", "未登录", "登录"):
+		return ErrNotSignedIn
+	case containsAny(m, "premium", "subscription", "subscribe", "会员"):
+		return ErrPremiumRequired
+	case containsAny(m, "too many", "too frequent", "rate limit", "频繁"):
+		return ErrRateLimited
+	case containsAny(m, "not found", "does not exist", "doesn't exist", "not exist", "不存在"):
+		return ErrNotFound
+	case containsAny(m, "internal server error", "internal error", "服务器错误"):
+		return ErrServer
+	}
+	return nil
+}
+
+func containsAny(s string, subs ...string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorMessage 从错误响应里找出服务端消息
+// 兼容 GraphQL 的 {"errors":[{"message":...}]} 和 REST 接口的 {"error":...} / {"detail":...}
+func errorMessage(body []byte) string {
+	var resp struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+		Error  string `json:"error"`
+		Detail string `json:"detail"`
+	}
+	if json.Unmarshal(body, &resp) != nil {
+		return ""
+	}
+	var msgs []string
+	for _, e := range resp.Errors {
+		if e.Message != "" {
+			msgs = append(msgs, e.Message)
+		}
+	}
+	switch {
+	case len(msgs) > 0:
+		return strings.Join(msgs, "; ")
+	case resp.Error != "":
+		return resp.Error
+	}
+	return resp.Detail
+}
+
+// hasData GraphQL 响应里有数据: data 不是 null，而且至少有一个字段不是 null
+// ({"data":{"question":null}} 这种和没有 data 一样，错误就是整个请求的错误)
+func hasData(body []byte) bool {
+	var resp struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(body, &resp) != nil {
+		return false
+	}
+	for _, v := range resp.Data {
+		if string(v) != "null" {
+			return true
+		}
+	}
+	return false
+}
+
+// newAPIError 按消息内容归类，认不出来时用 fallback
+func newAPIError(msg string, fallback error) *APIError {
+	kind := kindForMessage(msg)
+	if kind == nil {
+		kind = fallback
+	}
+	return &APIError{Kind: kind, Message: msg}
+}
+
+// isHTML 应该是 JSON 的接口返回了网页，一般是 Cookie 失效被重定向到了登录页
+func isHTML(resp *http.Response, body []byte) bool {
+	if strings.Contains(resp.Header.Get("Content-Type"), "text/html") {
+		return true
+	}
+	return strings.HasPrefix(strings.TrimSpace(string(body)), "<")
+}
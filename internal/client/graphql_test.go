@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/X-for/ltgo/internal/config"
+)
+
+func graphQLClient(t *testing.T, body string) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return &Client{http: &http.Client{}, cfg: &config.Config{}, BaseURL: srv.URL}
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	type result struct {
+		Data struct {
+			Question *struct {
+				Title string `json:"title"`
+			} `json:"question"`
+			User *struct{} `json:"user"`
+		} `json:"data"`
+	}
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+		title   string
+	}{
+		{"ok", `{"data":{"question":{"title":"Two Sum"}}}`, false, "Two Sum"},
+		{"partial", `{"data":{"question":{"title":"Two Sum"},"user":null},"errors":[{"message":"login required"}]}`, false, "Two Sum"},
+		{"null data", `{"data":null,"errors":[{"message":"boom"}]}`, true, ""},
+		{"no data", `{"errors":[{"message":"boom"}]}`, true, ""},
+		{"all fields null", `{"data":{"question":null},"errors":[{"message":"That question does not exist"}]}`, true, ""},
+	}
+	for _, tt := range tests {
+		c := graphQLClient(t, tt.body)
+		var res result
+		err := c.GraphQL(context.Background(), "query", nil, &res)
+		if tt.wantErr {
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Errorf("%s: got %v, want an APIError", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if res.Data.Question == nil || res.Data.Question.Title != tt.title {
+			t.Errorf("%s: question = %+v", tt.name, res.Data.Question)
+		}
+		if tt.name == "partial" && c.PartialErrors != "login required" {
+			t.Errorf("%s: PartialErrors = %q", tt.name, c.PartialErrors)
+		}
+	}
+}
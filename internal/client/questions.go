@@ -1,7 +1,9 @@
 package client
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/X-for/ltgo/internal/models"
@@ -13,9 +15,36 @@ type SearchOptions struct {
 	Status     string // "TO_DO", "SOLVED", "ATTEMPTED"
 	Tag        string // e.g. "array", "dynamic-programming"
 	FrontendID string // id of problem
+	MinID      int    // 前端 ID 范围 (含两端)，为 0 表示不限
+	MaxID      int
+	Sort       string // 排序字段: "id"、"acceptance"、"difficulty"，为空用默认顺序
+	Descending bool   // 降序
+	Skip       int    // 翻页: 跳过前面多少题
+	Limit      int    // 每页多少题，为 0 时用 20
 }
 
-func (c *Client) GetQuestions(limit, skip int) (*models.QuestionListResponse, error) {
+// sortFields SearchOptions.Sort -> QuestionSortByInput 的 sortField
+var sortFields = map[string]string{
+	"":           "CUSTOM",
+	"id":         "FRONTEND_ID",
+	"acceptance": "AC_RATE",
+	"difficulty": "DIFFICULTY",
+}
+
+// ValidSort 是否是支持的排序字段
+func ValidSort(sort string) bool {
+	_, ok := sortFields[sort]
+	return ok
+}
+
+// QuestionPage 按条件查到的一页题目
+type QuestionPage struct {
+	Questions []models.Question
+	Total     int  // 符合条件的总题数
+	HasMore   bool // 后面还有没有
+}
+
+func (c *Client) GetQuestions(ctx context.Context, limit, skip int) (*models.QuestionListResponse, error) {
 	// 专门针对 CN 的 V2 Query
 	// 删除了 filters 参数定义和传参
 	query := `
@@ -25,7 +54,10 @@ func (c *Client) GetQuestions(limit, skip int) (*models.QuestionListResponse, er
             limit: $limit
             skip: $skip
         ) {
+            totalLength
+            hasMore
             questions {
+                id
                 questionFrontendId
                 title
                 translatedTitle
@@ -45,7 +77,7 @@ func (c *Client) GetQuestions(limit, skip int) (*models.QuestionListResponse, er
 	}
 
 	var resp models.QuestionListResponse
-	if err := c.GraphQL(query, vars, &resp); err != nil {
+	if err := c.GraphQL(ctx, query, vars, &resp); err != nil {
 		return nil, err
 	}
 
@@ -53,17 +85,29 @@ func (c *Client) GetQuestions(limit, skip int) (*models.QuestionListResponse, er
 }
 
 // GetQuestionDetail 获取单题详情 (描述 + 代码模板)
-func (c *Client) GetQuestionDetail(titleSlug string) (*models.QuestionDetail, error) {
+// 优先读本地缓存，c.Refresh 为 true 时强制走网络
+func (c *Client) GetQuestionDetail(ctx context.Context, titleSlug string) (*models.QuestionDetail, error) {
+	if c.cache != nil && !c.Refresh {
+		if q, ok := c.cache.Get(titleSlug); ok {
+			return q, nil
+		}
+	}
+
 	query := `
     query questionData($titleSlug: String!) {
         question(titleSlug: $titleSlug) {
+            questionId
             questionFrontendId
             title
+            translatedTitle
             titleSlug
             content
             translatedContent
             difficulty
+            isPaidOnly
             sampleTestCase
+            exampleTestcases
+            metaData
             codeSnippets {
                 lang
                 langSlug
@@ -77,43 +121,67 @@ func (c *Client) GetQuestionDetail(titleSlug string) (*models.QuestionDetail, er
 	}
 
 	var resp models.QuestionDetailResponse
-	if err := c.GraphQL(query, vars, &resp); err != nil {
+	if err := c.GraphQL(ctx, query, vars, &resp); err != nil {
 		return nil, err
 	}
 
-	if resp.Data.Question.Title == "" {
-		return nil, errors.New("question not found")
+	q := &resp.Data.Question
+	if q.Title == "" {
+		return nil, &APIError{Kind: ErrNotFound, Message: fmt.Sprintf("question '%s' does not exist", titleSlug)}
+	}
+	// 没有会员时会员题只给标题，描述和代码模板都是空的
+	if q.IsPaidOnly && q.Content == "" && q.TranslatedContent == "" && len(q.CodeSnippets) == 0 {
+		return nil, &APIError{Kind: ErrPremiumRequired, Message: fmt.Sprintf("'%s' is a premium question", q.Title)}
 	}
 
-	return &resp.Data.Question, nil
+	if c.cache != nil {
+		// 缓存写失败不影响本次使用
+		_ = c.cache.Put(q)
+	}
+	return q, nil
 }
 
 // GetQuestionSlugByID 根据题目 ID (FrontendID) 查找 Slug
-// 注意：这是一个比较重的操作，因为可能需要拉取很多题目
-func (c *Client) GetQuestionSlugByID(id string) (string, error) {
-	// 假设题目在前 3000 个里 (绝大多数情况够用了)
-	resp, err := c.GetQuestions(3000, 0)
+// 走本地索引，只有索引过期或者查不到时才会增量同步
+func (c *Client) GetQuestionSlugByID(ctx context.Context, id string) (string, error) {
+	q, err := c.ResolveQuestion(ctx, id)
 	if err != nil {
 		return "", err
 	}
+	return q.TitleSlug, nil
+}
 
-	// 处理 V2 和 V1 兼容性
-	questions := resp.Data.ProblemsetQuestionListV2.Questions
-	if len(questions) == 0 {
-		questions = resp.Data.ProblemsetQuestionList.Questions
+// SearchQuestions 严格复刻抓包请求
+func (c *Client) SearchQuestions(ctx context.Context, opts SearchOptions) ([]models.Question, error) {
+	if opts.Keyword == "" {
+		opts.Keyword = opts.FrontendID
+	}
+	page, err := c.QueryQuestions(ctx, opts)
+	if err != nil {
+		return nil, err
 	}
+	questions := page.Questions
 
-	for _, q := range questions {
-		if q.QuestionFrontendID == id {
-			return q.TitleSlug, nil
+	// [新增] 客户端精确过滤 ID
+	if opts.FrontendID != "" {
+		var exactMatch []models.Question
+		for _, q := range questions {
+			if q.QuestionFrontendID == opts.FrontendID {
+				exactMatch = append(exactMatch, q)
+				break // 找到一个就够了，ID 是唯一的
+			}
 		}
+		// 如果找到了，就只返回这一条
+		// 如果没找到（可能是 filters 已经过滤太狠了，或者是翻页问题），那就返回空，或者返回原始列表（取决于策略）
+		// 这里我们选择：如果找到了就精确返回；没找到就返回空（因为用户明确要求了 ID）
+		return exactMatch, nil
 	}
 
-	return "", errors.New("question ID not found in the first 3000 questions")
+	return questions, nil
 }
 
-// SearchQuestions 严格复刻抓包请求
-func (c *Client) SearchQuestions(opts SearchOptions) ([]models.Question, error) {
+// QueryQuestions 按关键词和过滤条件查一页题目 (problemsetQuestionListV2)，用 Skip / Limit 翻页
+func (c *Client) QueryQuestions(ctx context.Context, opts SearchOptions) (*QuestionPage, error) {
 	query := `
     query problemsetQuestionListV2($filters: QuestionFilterInput, $limit: Int, $searchKeyword: String, $skip: Int, $sortBy: QuestionSortByInput, $categorySlug: String) {
       problemsetQuestionListV2(
@@ -124,6 +192,8 @@ func (c *Client) SearchQuestions(opts SearchOptions) ([]models.Question, error)
         sortBy: $sortBy
         categorySlug: $categorySlug
       ) {
+        totalLength
+        hasMore
         questions {
           titleSlug
           title
@@ -132,6 +202,7 @@ func (c *Client) SearchQuestions(opts SearchOptions) ([]models.Question, error)
           paidOnly
           difficulty
           status
+          acRate
         }
       }
     }`
@@ -194,56 +265,69 @@ func (c *Client) SearchQuestions(opts SearchOptions) ([]models.Question, error)
 		filters["difficultyFilter"].(map[string]interface{})["difficulties"] = []string{strings.ToUpper(opts.Difficulty)}
 	}
 	if opts.Status != "" {
-		filters["statusFilter"].(map[string]interface{})["questionStatuses"] = []string{strings.ToUpper(opts.Status)}
+		st := strings.ToUpper(opts.Status)
+		if st == "TODO" {
+			st = "TO_DO"
+		}
+		filters["statusFilter"].(map[string]interface{})["questionStatuses"] = []string{st}
 	}
 	if opts.Tag != "" {
 		filters["topicFilter"].(map[string]interface{})["topicSlugs"] = []string{opts.Tag}
 	}
-	if opts.Keyword == "" {
-		opts.Keyword = opts.FrontendID
+	if opts.MinID > 0 || opts.MaxID > 0 {
+		idRange := map[string]interface{}{}
+		if opts.MinID > 0 {
+			idRange["rangeLeft"] = opts.MinID
+		}
+		if opts.MaxID > 0 {
+			idRange["rangeRight"] = opts.MaxID
+		}
+		filters["frontendIdFilter"] = idRange
+	}
+
+	sortField, ok := sortFields[opts.Sort]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort field '%s'", opts.Sort)
+	}
+	sortOrder := "ASCENDING"
+	if opts.Descending {
+		sortOrder = "DESCENDING"
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 20
 	}
 
 	vars := map[string]interface{}{
-		"skip":          0,
-		"limit":         20,
+		"skip":          opts.Skip,
+		"limit":         limit,
 		"categorySlug":  category,
 		"searchKeyword": opts.Keyword,
 		"sortBy": map[string]interface{}{
-			"sortField": "CUSTOM",
-			"sortOrder": "ASCENDING",
+			"sortField": sortField,
+			"sortOrder": sortOrder,
 		},
 		"filters": filters,
 	}
 
 	var resp models.QuestionListResponse
-	if err := c.GraphQL(query, vars, &resp); err != nil {
+	if err := c.GraphQL(ctx, query, vars, &resp); err != nil {
 		return nil, err
 	}
 
-	questions := resp.Data.ProblemsetQuestionListV2.Questions
-	if len(questions) == 0 {
-		questions = resp.Data.ProblemsetQuestionList.Questions
+	list := resp.Data.ProblemsetQuestionListV2
+	page := &QuestionPage{Questions: list.Questions, Total: list.TotalLength, HasMore: list.HasMore}
+	if len(page.Questions) == 0 {
+		page.Questions = resp.Data.ProblemsetQuestionList.Questions
 	}
-	// [新增] 客户端精确过滤 ID
-	if opts.FrontendID != "" {
-		var exactMatch []models.Question
-		for _, q := range questions {
-			if q.QuestionFrontendID == opts.FrontendID {
-				exactMatch = append(exactMatch, q)
-				break // 找到一个就够了，ID 是唯一的
-			}
-		}
-		// 如果找到了，就只返回这一条
-		// 如果没找到（可能是 filters 已经过滤太狠了，或者是翻页问题），那就返回空，或者返回原始列表（取决于策略）
-		// 这里我们选择：如果找到了就精确返回；没找到就返回空（因为用户明确要求了 ID）
-		return exactMatch, nil
+	if page.Total == 0 {
+		page.Total = list.Total
 	}
-
-	return questions, nil
+	return page, nil
 }
 
 // GetDailyQuestion 获取每日一题
-func (c *Client) GetDailyQuestion() (*models.Question, error) {
+func (c *Client) GetDailyQuestion(ctx context.Context) (*models.Question, error) {
 	var query string
 	if c.cfg.Site == "cn" {
 		query = `
@@ -278,7 +362,7 @@ func (c *Client) GetDailyQuestion() (*models.Question, error) {
 
 	var resp models.DailyQuestionResponse
 	// 每日一题不需要变量
-	if err := c.GraphQL(query, nil, &resp); err != nil {
+	if err := c.GraphQL(ctx, query, nil, &resp); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultRetries 没有配置 retries 时的重试次数
+const DefaultRetries = 3
+
+// RetryPolicy 一类请求的重试策略
+type RetryPolicy struct {
+	Retries   int           // 最多重试几次，0 表示不重试
+	BaseDelay time.Duration // 第一次重试前等多久，之后每次翻倍
+	MaxDelay  time.Duration // 单次等待的上限；Retry-After 超过它就直接放弃
+
+	// Idempotent 为 true 时网络错误和 5xx 也重试 (GraphQL 查询、轮询结果)
+	// 为 false 时只重试 429：服务端明确拒绝了请求，重发不会导致重复提交
+	Idempotent bool
+}
+
+// readPolicy 查询类请求，重发没有副作用
+func (c *Client) readPolicy() RetryPolicy {
+	return RetryPolicy{Retries: c.retries, BaseDelay: 500 * time.Millisecond, MaxDelay: 10 * time.Second, Idempotent: true}
+}
+
+// submitPolicy 运行 / 提交代码，只在被限流时重发
+func (c *Client) submitPolicy() RetryPolicy {
+	return RetryPolicy{Retries: c.retries, BaseDelay: 2 * time.Second, MaxDelay: 30 * time.Second}
+}
+
+// StatusError 服务端返回了 4xx / 5xx
+// errors.Is 可以判断出对应的 Err* (429 是 ErrRateLimited，5xx 是 ErrServer ...)
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Message    string        // 响应体里的错误消息，可能为空
+	RetryAfter time.Duration // 响应里的 Retry-After，没有时为 0
+	Kind       error
+}
+
+func (e *StatusError) Error() string {
+	msg := "server returned " + e.Status
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(" (retry after %s)", e.RetryAfter)
+	}
+	return msg
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Kind
+}
+
+// RetryError 重试次数用完了还是失败
+type RetryError struct {
+	Attempts int
+	Err      error // 最后一次的错误
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("giving up after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// retryable 这次失败值不值得重试
+func (p RetryPolicy) retryable(err error) bool {
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.StatusCode == http.StatusTooManyRequests || (p.Idempotent && se.StatusCode >= 500)
+	}
+	var ae *APIError
+	if errors.As(err, &ae) {
+		// 服务端明确回了错误 (登录页、会员题...)，重试也一样
+		return false
+	}
+	// 其余都是网络层面的错误
+	return p.Idempotent
+}
+
+// delay 第 attempt 次重试前要等多久 (指数退避 + 抖动)，ok 为 false 表示不值得等了
+func (p RetryPolicy) delay(attempt int, err error) (d time.Duration, ok bool) {
+	var se *StatusError
+	if errors.As(err, &se) && se.RetryAfter > 0 {
+		return se.RetryAfter, se.RetryAfter <= p.MaxDelay
+	}
+
+	d = p.BaseDelay << attempt
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	// 在 [d/2, d) 之间随机，避免多个请求同时醒来
+	half := d / 2
+	return half + rand.N(half+1), true
+}
+
+// statusError 4xx / 5xx 转成 StatusError，其他返回 nil
+func statusError(resp *http.Response, body []byte) error {
+	if resp.StatusCode < 400 {
+		return nil
+	}
+	msg := errorMessage(body)
+	kind := kindForStatus(resp.StatusCode)
+	if k := kindForMessage(msg); k != nil && (kind == nil || resp.StatusCode == http.StatusForbidden) {
+		// 400 看消息；403 也可能是会员题
+		kind = k
+	}
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Message:    msg,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		Kind:       kind,
+	}
+}
+
+// parseRetryAfter 支持秒数和 HTTP 日期两种写法
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
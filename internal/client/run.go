@@ -1,9 +1,9 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"time"
 
 	"github.com/X-for/ltgo/internal/models"
 )
@@ -33,19 +33,27 @@ type CheckResponse struct {
 }
 
 // RunCode 提交运行任务
-func (c *Client) RunCode(q *models.QuestionDetail, code string, lang string) (string, error) {
+// input 是 data_input (每行一个参数)，为空时用题目自带的 SampleTestCase
+func (c *Client) RunCode(ctx context.Context, q *models.QuestionDetail, code string, lang string, input string) (string, error) {
+	if input == "" {
+		input = q.SampleTestCase
+	}
+	if err := c.requireCSRF(); err != nil {
+		return "", err
+	}
+
 	// 1. 构造请求 Payload
 	payload := map[string]interface{}{
 		"lang":        lang,
 		"question_id": q.QuestionFrontendID, // 注意：有些时候这里需要 QuestionID (后端ID)，而非 FrontendID
 		"typed_code":  code,
-		"data_input":  q.SampleTestCase,
+		"data_input":  input,
 	}
 
 	body, _ := json.Marshal(payload)
 	path := fmt.Sprintf("/problems/%s/interpret_solution/", q.TitleSlug)
 
-	respBody, err := c.Post(path, body)
+	respBody, err := c.Post(ctx, path, body)
 	if err != nil {
 		return "", err
 	}
@@ -56,17 +64,24 @@ func (c *Client) RunCode(q *models.QuestionDetail, code string, lang string) (st
 	if err := json.Unmarshal(respBody, &resp); err != nil {
 		return "", err
 	}
+	if resp.InterpretID == "" {
+		// 比如提交太频繁、会员题，接口会返回 {"error": "..."}
+		if msg := errorMessage(respBody); msg != "" {
+			return "", newAPIError(msg, nil)
+		}
+		return "", fmt.Errorf("unexpected response: %s", respBody)
+	}
 
 	return resp.InterpretID, nil
 }
 
 // CheckResult 轮询结果
-func (c *Client) CheckResult(interpretID string) (*CheckResponse, error) {
+func (c *Client) CheckResult(ctx context.Context, interpretID string) (*CheckResponse, error) {
 	path := fmt.Sprintf("/submissions/detail/%s/check/", interpretID)
 
 	// 轮询几次，每次间隔 1-2 秒
 	for i := 0; i < 20; i++ {
-		respBody, err := c.Get(path)
+		respBody, err := c.Get(ctx, path)
 		if err != nil {
 			return nil, err
 		}
@@ -82,7 +97,9 @@ func (c *Client) CheckResult(interpretID string) (*CheckResponse, error) {
 		}
 
 		// 还没跑完，等一下
-		time.Sleep(1 * time.Second)
+		if err := wait(ctx, pollInterval); err != nil {
+			return nil, err
+		}
 	}
 
 	return nil, fmt.Errorf("timeout waiting for result")
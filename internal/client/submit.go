@@ -1,9 +1,9 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"time"
 
 	"github.com/X-for/ltgo/internal/models"
 )
@@ -33,13 +33,18 @@ type SubmitCheckResponse struct {
 
 	// 错误时的详细信息
 	InputFormatted string `json:"input_formatted"` // 出错的那个 case 的输入
+	LastTestcase   string `json:"last_testcase"`   // 同上，原始格式 (每行一个参数)
 	CodeOutput     string `json:"code_output"`     // 你的输出
 	ExpectedOutput string `json:"expected_output"` // 预期输出
 	StdOutput      string `json:"std_output"`      // 你的打印
 }
 
 // SubmitCode 提交代码进行判题
-func (c *Client) SubmitCode(q *models.QuestionDetail, code string, lang string) (int64, error) {
+func (c *Client) SubmitCode(ctx context.Context, q *models.QuestionDetail, code string, lang string) (int64, error) {
+	if err := c.requireCSRF(); err != nil {
+		return 0, err
+	}
+
 	payload := map[string]interface{}{
 		"lang":        lang,
 		"question_id": q.QuestionID, // ⚠️ 注意：提交通常需要 QuestionID (后端ID)，不是 FrontendID
@@ -49,7 +54,7 @@ func (c *Client) SubmitCode(q *models.QuestionDetail, code string, lang string)
 	body, _ := json.Marshal(payload)
 	path := fmt.Sprintf("/problems/%s/submit/", q.TitleSlug)
 
-	respBody, err := c.Post(path, body)
+	respBody, err := c.Post(ctx, path, body)
 	if err != nil {
 		return 0, err
 	}
@@ -58,16 +63,23 @@ func (c *Client) SubmitCode(q *models.QuestionDetail, code string, lang string)
 	if err := json.Unmarshal(respBody, &resp); err != nil {
 		return 0, err
 	}
+	if resp.SubmissionID == 0 {
+		// 比如提交太频繁、会员题，接口会返回 {"error": "..."}
+		if msg := errorMessage(respBody); msg != "" {
+			return 0, newAPIError(msg, nil)
+		}
+		return 0, fmt.Errorf("unexpected response: %s", respBody)
+	}
 
 	return resp.SubmissionID, nil
 }
 
 // CheckSubmission 轮询提交结果
-func (c *Client) CheckSubmission(submissionID int64) (*SubmitCheckResponse, error) {
+func (c *Client) CheckSubmission(ctx context.Context, submissionID int64) (*SubmitCheckResponse, error) {
 	path := fmt.Sprintf("/submissions/detail/%d/check/", submissionID)
 
 	for i := 0; i < 20; i++ {
-		respBody, err := c.Get(path)
+		respBody, err := c.Get(ctx, path)
 		if err != nil {
 			return nil, err
 		}
@@ -81,7 +93,9 @@ func (c *Client) CheckSubmission(submissionID int64) (*SubmitCheckResponse, erro
 			return &resp, nil
 		}
 
-		time.Sleep(1 * time.Second)
+		if err := wait(ctx, pollInterval); err != nil {
+			return nil, err
+		}
 	}
 
 	return nil, fmt.Errorf("timeout waiting for submission result")
@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/X-for/ltgo/internal/index"
+	"github.com/X-for/ltgo/internal/models"
+)
+
+// syncPageSize 每页拉取的题目数 (problemsetQuestionListV2 一次给几百条没问题)
+const syncPageSize = 500
+
+// Index 返回本地题目索引 (懒加载，同一个 Client 只读一次磁盘)
+func (c *Client) Index() (*index.Index, error) {
+	if c.index != nil {
+		return c.index, nil
+	}
+	ix, err := index.Load(c.cfg.Site)
+	if err != nil {
+		return nil, err
+	}
+	c.index = ix
+	return ix, nil
+}
+
+// SyncIndex 分页拉取题库并写入本地索引
+// full 为 true 时清空重建；否则先看服务端的题目总数，和上次一样就不用拉，
+// 变了就从头拉一遍合并进索引 (新题按前端 ID 排序，不一定排在最后，只拉尾部会漏)
+// progress 可以为 nil，用来汇报进度 (已拉取, 总数)
+func (c *Client) SyncIndex(ctx context.Context, full bool, progress func(done, total int)) (added int, err error) {
+	ix, err := c.Index()
+	if err != nil {
+		return 0, err
+	}
+
+	if full {
+		ix.Reset()
+	} else if ix.Total > 0 {
+		resp, err := c.GetQuestions(ctx, 1, 0)
+		if err != nil {
+			return 0, err
+		}
+		if total := listTotal(resp); total == ix.Total {
+			ix.UpdatedAt = time.Now()
+			return 0, ix.Save()
+		}
+	}
+
+	skip := 0
+	total := ix.Total
+	for {
+		resp, err := c.GetQuestions(ctx, syncPageSize, skip)
+		if err != nil {
+			return added, err
+		}
+
+		list := resp.Data.ProblemsetQuestionListV2
+		questions := list.Questions
+		if len(questions) == 0 {
+			questions = resp.Data.ProblemsetQuestionList.Questions
+		}
+		if t := listTotal(resp); t > 0 {
+			total = t
+		}
+
+		added += ix.Merge(toEntries(questions))
+		skip += len(questions)
+		if progress != nil {
+			progress(skip, total)
+		}
+
+		// V2 有 hasMore；旧接口没有，就看这一页是不是满的
+		if len(questions) == 0 || (!list.HasMore && len(questions) < syncPageSize) {
+			break
+		}
+		if total > 0 && skip >= total {
+			break
+		}
+	}
+
+	if total < skip {
+		total = skip
+	}
+	ix.Total = total
+	ix.UpdatedAt = time.Now()
+	return added, ix.Save()
+}
+
+// ResolveQuestion 用前端 ID 或 slug 从本地索引里找题
+// 索引过期或者没找到时会先增量同步一次再查
+func (c *Client) ResolveQuestion(ctx context.Context, key string) (*models.Question, error) {
+	ix, err := c.Index()
+	if err != nil {
+		return nil, err
+	}
+
+	if e, ok := ix.Lookup(key); ok && !ix.Stale() {
+		q := e.Question()
+		return &q, nil
+	}
+
+	// 索引为空时等价于一次全量同步
+	if c.SyncProgress != nil {
+		c.SyncProgress(0, ix.Total)
+	}
+	if _, err := c.SyncIndex(ctx, false, c.SyncProgress); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// 同步失败但旧索引里有，就先凑合用
+		if e, ok := ix.Lookup(key); ok {
+			q := e.Question()
+			return &q, nil
+		}
+		return nil, fmt.Errorf("failed to refresh problem index: %w", err)
+	}
+
+	if e, ok := ix.Lookup(key); ok {
+		q := e.Question()
+		return &q, nil
+	}
+	return nil, fmt.Errorf("question '%s' not found in local index (try 'ltgo sync --full')", key)
+}
+
+// listTotal 题目总数，接口没给时为 0
+func listTotal(resp *models.QuestionListResponse) int {
+	if t := resp.Data.ProblemsetQuestionListV2.TotalLength; t > 0 {
+		return t
+	}
+	return resp.Data.ProblemsetQuestionList.Total
+}
+
+func toEntries(questions []models.Question) []index.Entry {
+	entries := make([]index.Entry, 0, len(questions))
+	for _, q := range questions {
+		entries = append(entries, index.FromQuestion(q))
+	}
+	return entries
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/X-for/ltgo/internal/config"
+	"github.com/X-for/ltgo/internal/models"
+)
+
+// problemset 假的题库接口，按 skip / limit 分页，requests 记录每次的 limit
+type problemset struct {
+	questions []models.Question
+	requests  []int
+}
+
+func (p *problemset) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Variables struct {
+			Skip  int `json:"skip"`
+			Limit int `json:"limit"`
+		} `json:"variables"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	p.requests = append(p.requests, req.Variables.Limit)
+
+	start := min(req.Variables.Skip, len(p.questions))
+	end := min(start+req.Variables.Limit, len(p.questions))
+	var resp models.QuestionListResponse
+	list := &resp.Data.ProblemsetQuestionListV2
+	list.TotalLength = len(p.questions)
+	list.HasMore = end < len(p.questions)
+	list.Questions = p.questions[start:end]
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func question(id, slug string) models.Question {
+	return models.Question{ID: json.Number(id), QuestionFrontendID: id, Title: slug, TitleSlug: slug}
+}
+
+// 新题插在中间 (按前端 ID 排序) 时，增量同步也要拉到
+func TestSyncIndexInsertedProblem(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	// 题目多到只拉尾部的话会跳过开头
+	var all []models.Question
+	for i := 1; i <= 120; i++ {
+		all = append(all, question(strconv.Itoa(i), "q"+strconv.Itoa(i)))
+	}
+	p := &problemset{questions: append(append([]models.Question{}, all[:2]...), all[3:]...)}
+	srv := httptest.NewServer(p)
+	defer srv.Close()
+	c := &Client{http: &http.Client{}, cfg: &config.Config{Site: "com"}, BaseURL: srv.URL}
+
+	if _, err := c.SyncIndex(context.Background(), false, nil); err != nil {
+		t.Fatalf("first sync: %v", err)
+	}
+
+	// 数量没变: 只问一次总数
+	p.requests = nil
+	if added, err := c.SyncIndex(context.Background(), false, nil); err != nil || added != 0 {
+		t.Fatalf("unchanged sync: added %d, %v", added, err)
+	}
+	if len(p.requests) != 1 || p.requests[0] != 1 {
+		t.Errorf("unchanged sync made requests %v, want one probe", p.requests)
+	}
+
+	p.questions = all
+	added, err := c.SyncIndex(context.Background(), false, nil)
+	if err != nil {
+		t.Fatalf("sync after insert: %v", err)
+	}
+	if added != 1 {
+		t.Errorf("added = %d, want 1", added)
+	}
+	ix, _ := c.Index()
+	if _, ok := ix.Lookup("3"); !ok {
+		t.Error("problem inserted in the middle is missing from the index")
+	}
+	if ix.Total != 120 {
+		t.Errorf("Total = %d, want 120", ix.Total)
+	}
+}
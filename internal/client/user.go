@@ -1,5 +1,7 @@
 package client
 
+import "context"
+
 // UserStatus 简单的用户状态模型
 type UserStatus struct {
 	IsSignedIn bool   `json:"isSignedIn"`
@@ -13,7 +15,7 @@ type UserStatusResponse struct {
 }
 
 // GetUser 获取当前登录用户信息
-func (c *Client) GetUser() (*UserStatus, error) {
+func (c *Client) GetUser(ctx context.Context) (*UserStatus, error) {
 	query := `
     query globalData {
         userStatus {
@@ -23,7 +25,7 @@ func (c *Client) GetUser() (*UserStatus, error) {
     }`
 
 	var resp UserStatusResponse
-	if err := c.GraphQL(query, nil, &resp); err != nil {
+	if err := c.GraphQL(ctx, query, nil, &resp); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,682 @@
+package codec
+
+// 这个文件会被嵌进本地测试程序 (见 embed.go)，和题解代码编译在同一个 package 里，
+// 所以只能依赖标准库，未导出的名字都带 codec 前缀，免得和题解里的名字撞上；
+// 导出的函数和常量嵌进去时由 Source 加上 codec 前缀 (Decode -> codecDecode)。
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ListNode LeetCode 的单链表节点
+type ListNode struct {
+	Val  int
+	Next *ListNode
+}
+
+// TreeNode LeetCode 的二叉树节点
+type TreeNode struct {
+	Val   int
+	Left  *TreeNode
+	Right *TreeNode
+}
+
+// FloatTolerance 比较浮点数时允许的误差 (和 LeetCode 判题一致)
+const FloatTolerance = 1e-5
+
+var (
+	codecListType = reflect.TypeOf((*ListNode)(nil))
+	codecTreeType = reflect.TypeOf((*TreeNode)(nil))
+)
+
+// codecNode 解析后的 LeetCode 值
+type codecNode struct {
+	kind  byte   // 'n' null, 'b' bool, '#' number, 's' string, '[' array
+	text  string // bool / number 的原文，string 的内容 (已反转义)
+	items []*codecNode
+}
+
+// Validate 检查文本是不是一个合法的 LeetCode 值
+func Validate(s string) error {
+	_, err := codecParse(s)
+	return err
+}
+
+// Canonical 去掉多余的空白，得到紧凑格式 (如 "[1, 2]" -> "[1,2]")
+func Canonical(s string) (string, error) {
+	n, err := codecParse(s)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	codecWriteNode(&sb, n)
+	return sb.String(), nil
+}
+
+// Equal 按值比较两个 LeetCode 文本，整数精确比较，带小数或指数的数允许 FloatTolerance 的误差
+// 解析不了的按去掉空白后的文本比较
+func Equal(a, b string) bool {
+	na, errA := codecParse(a)
+	nb, errB := codecParse(b)
+	if errA != nil || errB != nil {
+		return strings.Join(strings.Fields(a), "") == strings.Join(strings.Fields(b), "")
+	}
+	return codecEqualNode(na, nb)
+}
+
+// Split 把一个数组拆成每个元素的文本 (紧凑格式)
+// 设计类题目的参数是 [[],[-2],[0]] 这种嵌套数组，要先拆开再按各自的类型解码
+func Split(s string) ([]string, error) {
+	n, err := codecParse(s)
+	if err != nil {
+		return nil, err
+	}
+	if n.kind != '[' {
+		return nil, fmt.Errorf("codec: expected an array, got %s", codecKindName(n))
+	}
+	out := make([]string, len(n.items))
+	for i, item := range n.items {
+		var sb strings.Builder
+		codecWriteNode(&sb, item)
+		out[i] = sb.String()
+	}
+	return out, nil
+}
+
+// Decode 把 LeetCode 文本解析到 v 指向的 Go 值
+// 支持整数、浮点、bool、string、byte (字符)、切片、*ListNode、*TreeNode 和 interface{}
+func Decode(s string, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("codec: Decode needs a non-nil pointer")
+	}
+	n, err := codecParse(s)
+	if err != nil {
+		return err
+	}
+	return codecDecodeValue(n, rv.Elem())
+}
+
+// Encode 把 Go 值序列化成 LeetCode 文本 (紧凑格式)
+// 浮点数保留 5 位小数，byte 当作字符输出
+func Encode(v any) string {
+	var sb strings.Builder
+	codecEncodeValue(&sb, reflect.ValueOf(v))
+	return sb.String()
+}
+
+// ---- 解析 ----
+
+type codecParser struct {
+	s   string
+	pos int
+}
+
+func codecParse(s string) (*codecNode, error) {
+	p := &codecParser{s: s}
+	n, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.s) {
+		return nil, p.errorf("unexpected %q after value", p.s[p.pos:])
+	}
+	return n, nil
+}
+
+func (p *codecParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("codec: at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *codecParser) skipSpace() {
+	for p.pos < len(p.s) && strings.ContainsRune(" \t\r\n", rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *codecParser) value() (*codecNode, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return nil, p.errorf("unexpected end of input")
+	}
+
+	switch c := p.s[p.pos]; {
+	case c == '[':
+		return p.array()
+	case c == '"':
+		str, err := p.str()
+		if err != nil {
+			return nil, err
+		}
+		return &codecNode{kind: 's', text: str}, nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.number()
+	case strings.HasPrefix(p.s[p.pos:], "null"):
+		p.pos += 4
+		return &codecNode{kind: 'n'}, nil
+	case strings.HasPrefix(p.s[p.pos:], "true"):
+		p.pos += 4
+		return &codecNode{kind: 'b', text: "true"}, nil
+	case strings.HasPrefix(p.s[p.pos:], "false"):
+		p.pos += 5
+		return &codecNode{kind: 'b', text: "false"}, nil
+	default:
+		return nil, p.errorf("unexpected character %q", c)
+	}
+}
+
+func (p *codecParser) array() (*codecNode, error) {
+	p.pos++ // [
+	n := &codecNode{kind: '['}
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == ']' {
+		p.pos++
+		return n, nil
+	}
+	for {
+		item, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		n.items = append(n.items, item)
+
+		p.skipSpace()
+		if p.pos >= len(p.s) {
+			return nil, p.errorf("unterminated array")
+		}
+		switch p.s[p.pos] {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return n, nil
+		default:
+			return nil, p.errorf("expected ',' or ']' but got %q", p.s[p.pos])
+		}
+	}
+}
+
+func (p *codecParser) number() (*codecNode, error) {
+	start := p.pos
+	if p.s[p.pos] == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.s) && strings.IndexByte("0123456789.eE+-", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	text := p.s[start:p.pos]
+	if _, err := strconv.ParseFloat(text, 64); err != nil {
+		p.pos = start
+		return nil, p.errorf("invalid number %q", text)
+	}
+	return &codecNode{kind: '#', text: text}, nil
+}
+
+func (p *codecParser) str() (string, error) {
+	p.pos++ // "
+	var sb strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			return sb.String(), nil
+		case c == '\\':
+			if p.pos+1 >= len(p.s) {
+				return "", p.errorf("unterminated escape")
+			}
+			p.pos++
+			switch e := p.s[p.pos]; e {
+			case '"', '\\', '/':
+				sb.WriteByte(e)
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'u':
+				if p.pos+4 >= len(p.s) {
+					return "", p.errorf("invalid \\u escape")
+				}
+				r, err := strconv.ParseUint(p.s[p.pos+1:p.pos+5], 16, 32)
+				if err != nil {
+					return "", p.errorf("invalid \\u escape")
+				}
+				sb.WriteRune(rune(r))
+				p.pos += 4
+			default:
+				return "", p.errorf("invalid escape \\%c", e)
+			}
+			p.pos++
+		default:
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// ---- 解码到 Go 值 ----
+
+func codecDecodeValue(n *codecNode, rv reflect.Value) error {
+	switch rv.Type() {
+	case codecListType:
+		return codecDecodeList(n, rv)
+	case codecTreeType:
+		return codecDecodeTree(n, rv)
+	}
+
+	switch rv.Kind() {
+	case reflect.Interface:
+		if rv.NumMethod() != 0 {
+			break
+		}
+		if n.kind == 'n' {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		rv.Set(reflect.ValueOf(codecGeneric(n)))
+		return nil
+
+	case reflect.Bool:
+		if n.kind != 'b' {
+			return codecMismatch(n, rv)
+		}
+		rv.SetBool(n.text == "true")
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// int32 就是 rune，允许写成单字符的字符串
+		if n.kind == 's' && rv.Kind() == reflect.Int32 && utf8.RuneCountInString(n.text) == 1 {
+			r, _ := utf8.DecodeRuneInString(n.text)
+			rv.SetInt(int64(r))
+			return nil
+		}
+		if n.kind != '#' {
+			return codecMismatch(n, rv)
+		}
+		i, err := strconv.ParseInt(n.text, 10, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("codec: %q is not a valid %s", n.text, rv.Type())
+		}
+		rv.SetInt(i)
+		return nil
+
+	case reflect.Uint8:
+		// byte 在题目里是 char，写成 "a"
+		if n.kind == 's' && len(n.text) == 1 {
+			rv.SetUint(uint64(n.text[0]))
+			return nil
+		}
+		fallthrough
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n.kind != '#' {
+			return codecMismatch(n, rv)
+		}
+		u, err := strconv.ParseUint(n.text, 10, rv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("codec: %q is not a valid %s", n.text, rv.Type())
+		}
+		rv.SetUint(u)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		if n.kind != '#' {
+			return codecMismatch(n, rv)
+		}
+		f, _ := strconv.ParseFloat(n.text, rv.Type().Bits())
+		rv.SetFloat(f)
+		return nil
+
+	case reflect.String:
+		if n.kind != 's' {
+			return codecMismatch(n, rv)
+		}
+		rv.SetString(n.text)
+		return nil
+
+	case reflect.Slice:
+		if n.kind == 'n' {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		if n.kind != '[' {
+			return codecMismatch(n, rv)
+		}
+		s := reflect.MakeSlice(rv.Type(), len(n.items), len(n.items))
+		for i, item := range n.items {
+			if err := codecDecodeValue(item, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		rv.Set(s)
+		return nil
+
+	case reflect.Array:
+		if n.kind != '[' || len(n.items) != rv.Len() {
+			return codecMismatch(n, rv)
+		}
+		for i, item := range n.items {
+			if err := codecDecodeValue(item, rv.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Pointer:
+		if n.kind == 'n' {
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		}
+		p := reflect.New(rv.Type().Elem())
+		if err := codecDecodeValue(n, p.Elem()); err != nil {
+			return err
+		}
+		rv.Set(p)
+		return nil
+	}
+
+	return fmt.Errorf("codec: unsupported type %s", rv.Type())
+}
+
+func codecMismatch(n *codecNode, rv reflect.Value) error {
+	return fmt.Errorf("codec: cannot decode %s into %s", codecKindName(n), rv.Type())
+}
+
+func codecKindName(n *codecNode) string {
+	switch n.kind {
+	case 'n':
+		return "null"
+	case 'b':
+		return "bool"
+	case '#':
+		return "number"
+	case 's':
+		return "string"
+	default:
+		return "array"
+	}
+}
+
+// codecGeneric 没有类型信息时 (interface{}) 的解码结果:
+// 整数 -> int，其他数字 -> float64，数组 -> []any
+func codecGeneric(n *codecNode) any {
+	switch n.kind {
+	case 'b':
+		return n.text == "true"
+	case '#':
+		if i, err := strconv.Atoi(n.text); err == nil {
+			return i
+		}
+		f, _ := strconv.ParseFloat(n.text, 64)
+		return f
+	case 's':
+		return n.text
+	case '[':
+		out := make([]any, len(n.items))
+		for i, item := range n.items {
+			if item.kind != 'n' {
+				out[i] = codecGeneric(item)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+func codecInts(n *codecNode, allowNull bool) ([]*int, error) {
+	if n.kind == 'n' {
+		return nil, nil
+	}
+	if n.kind != '[' {
+		return nil, errors.New("codec: expected an array")
+	}
+	vals := make([]*int, len(n.items))
+	for i, item := range n.items {
+		if item.kind == 'n' && allowNull {
+			continue
+		}
+		if item.kind != '#' {
+			return nil, fmt.Errorf("codec: invalid node value at index %d", i)
+		}
+		v, err := strconv.Atoi(item.text)
+		if err != nil {
+			return nil, fmt.Errorf("codec: invalid node value %q", item.text)
+		}
+		vals[i] = &v
+	}
+	return vals, nil
+}
+
+func codecDecodeList(n *codecNode, rv reflect.Value) error {
+	vals, err := codecInts(n, false)
+	if err != nil {
+		return err
+	}
+	dummy := &ListNode{}
+	cur := dummy
+	for _, v := range vals {
+		cur.Next = &ListNode{Val: *v}
+		cur = cur.Next
+	}
+	rv.Set(reflect.ValueOf(dummy.Next))
+	return nil
+}
+
+// codecDecodeTree 按层序 (带 null) 还原二叉树
+func codecDecodeTree(n *codecNode, rv reflect.Value) error {
+	vals, err := codecInts(n, true)
+	if err != nil {
+		return err
+	}
+	if len(vals) == 0 || vals[0] == nil {
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	}
+
+	root := &TreeNode{Val: *vals[0]}
+	queue := []*TreeNode{root}
+	for i := 1; i < len(vals); {
+		if len(queue) == 0 {
+			return errors.New("codec: invalid level-order tree")
+		}
+		node := queue[0]
+		queue = queue[1:]
+
+		if vals[i] != nil {
+			node.Left = &TreeNode{Val: *vals[i]}
+			queue = append(queue, node.Left)
+		}
+		i++
+		if i < len(vals) && vals[i] != nil {
+			node.Right = &TreeNode{Val: *vals[i]}
+			queue = append(queue, node.Right)
+		}
+		i++
+	}
+	rv.Set(reflect.ValueOf(root))
+	return nil
+}
+
+// ---- 编码 ----
+
+func codecEncodeValue(sb *strings.Builder, rv reflect.Value) {
+	if !rv.IsValid() {
+		sb.WriteString("null")
+		return
+	}
+
+	switch rv.Type() {
+	case codecListType:
+		sb.WriteByte('[')
+		for i, node := 0, rv.Interface().(*ListNode); node != nil; i, node = i+1, node.Next {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(strconv.Itoa(node.Val))
+		}
+		sb.WriteByte(']')
+		return
+	case codecTreeType:
+		codecEncodeTree(sb, rv.Interface().(*TreeNode))
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		sb.WriteString(strconv.FormatBool(rv.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sb.WriteString(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint8:
+		codecQuote(sb, string([]byte{byte(rv.Uint())}))
+	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		sb.WriteString(strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		sb.WriteString(strconv.FormatFloat(rv.Float(), 'f', 5, 64))
+	case reflect.String:
+		codecQuote(sb, rv.String())
+	case reflect.Slice, reflect.Array:
+		sb.WriteByte('[')
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			codecEncodeValue(sb, rv.Index(i))
+		}
+		sb.WriteByte(']')
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			sb.WriteString("null")
+			return
+		}
+		codecEncodeValue(sb, rv.Elem())
+	default:
+		fmt.Fprint(sb, rv.Interface())
+	}
+}
+
+func codecEncodeTree(sb *strings.Builder, root *TreeNode) {
+	var vals []*TreeNode
+	queue := []*TreeNode{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		vals = append(vals, node)
+		if node != nil {
+			queue = append(queue, node.Left, node.Right)
+		}
+	}
+	// 去掉末尾的 null
+	for len(vals) > 0 && vals[len(vals)-1] == nil {
+		vals = vals[:len(vals)-1]
+	}
+
+	sb.WriteByte('[')
+	for i, node := range vals {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		if node == nil {
+			sb.WriteString("null")
+		} else {
+			sb.WriteString(strconv.Itoa(node.Val))
+		}
+	}
+	sb.WriteByte(']')
+}
+
+func codecQuote(sb *strings.Builder, s string) {
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(sb, `\u%04x`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+}
+
+func codecWriteNode(sb *strings.Builder, n *codecNode) {
+	switch n.kind {
+	case 'n':
+		sb.WriteString("null")
+	case 's':
+		codecQuote(sb, n.text)
+	case '[':
+		sb.WriteByte('[')
+		for i, item := range n.items {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			codecWriteNode(sb, item)
+		}
+		sb.WriteByte(']')
+	default:
+		sb.WriteString(n.text)
+	}
+}
+
+func codecEqualNode(a, b *codecNode) bool {
+	if a.kind != b.kind {
+		return false
+	}
+	switch a.kind {
+	case '#':
+		if a.text == b.text {
+			return true
+		}
+		if codecIsInt(a.text) && codecIsInt(b.text) {
+			// 整数按原样精确比较，超过 float64 精度的大数也不能算相等
+			ia, okA := new(big.Int).SetString(a.text, 10)
+			ib, okB := new(big.Int).SetString(b.text, 10)
+			return okA && okB && ia.Cmp(ib) == 0
+		}
+		fa, _ := strconv.ParseFloat(a.text, 64)
+		fb, _ := strconv.ParseFloat(b.text, 64)
+		return math.Abs(fa-fb) <= FloatTolerance
+	case '[':
+		if len(a.items) != len(b.items) {
+			return false
+		}
+		for i := range a.items {
+			if !codecEqualNode(a.items[i], b.items[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a.text == b.text
+	}
+}
+
+// codecIsInt 数字文本没有小数部分也没有指数
+func codecIsInt(text string) bool {
+	return !strings.ContainsAny(text, ".eE")
+}
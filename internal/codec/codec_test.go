@@ -0,0 +1,270 @@
+package codec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"[1,2,3]", "[1, 2, 3]", true},
+		{"[1,2,3]", "[1,2]", false},
+		{"9007199254740993", "9007199254740992", false},
+		{"9007199254740993", "9007199254740993", true},
+		{"100000000000000000000", "100000000000000000001", false},
+		{"1", "2", false},
+		{"2.00000", "2", true},
+		{"0.333333", "0.33333", true},
+		{"0.3334", "0.3333", false},
+		{"1e5", "100000", true},
+		{`"abc"`, `"abc"`, true},
+		{`"abc"`, `"abd"`, false},
+		{"true", "1", false},
+		{"null", "[]", false},
+		{"[[1],[2,3]]", "[[1],[2,3]]", true},
+		{"not json", "not  json", true},
+	}
+	for _, tt := range tests {
+		if got := Equal(tt.a, tt.b); got != tt.want {
+			t.Errorf("Equal(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	var (
+		i    int
+		f    float64
+		b    bool
+		s    string
+		c    byte
+		r    rune
+		ints []int
+		grid [][]string
+		strs []string
+		gen  any
+	)
+	tests := []struct {
+		in   string
+		v    any
+		want any
+	}{
+		{"42", &i, 42},
+		{"-7", &i, -7},
+		{"2.5", &f, 2.5},
+		{"true", &b, true},
+		{`"a\"b\n"`, &s, "a\"b\n"},
+		{`"x"`, &c, byte('x')},
+		{`"中"`, &r, '中'},
+		{"[1, 2, 3]", &ints, []int{1, 2, 3}},
+		{"[]", &ints, []int{}},
+		{"null", &ints, []int(nil)},
+		{`[["1","0"],["0","1"]]`, &grid, [][]string{{"1", "0"}, {"0", "1"}}},
+		{`["ab","cd"]`, &strs, []string{"ab", "cd"}},
+		{"[1,2.5,null]", &gen, []any{1, 2.5, nil}},
+	}
+	for _, tt := range tests {
+		if err := Decode(tt.in, tt.v); err != nil {
+			t.Errorf("Decode(%s): %v", tt.in, err)
+			continue
+		}
+		if got := reflect.ValueOf(tt.v).Elem().Interface(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Decode(%s) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	var (
+		i    int
+		i8   int8
+		ints []int
+		s    string
+	)
+	tests := []struct {
+		in string
+		v  any
+	}{
+		{"[1,2", &ints},
+		{`"1"`, &i},
+		{"1.5", &i},
+		{"300", &i8},
+		{"[1,true]", &ints},
+		{"1", &s},
+		{"1 2", &i},
+	}
+	for _, tt := range tests {
+		if err := Decode(tt.in, tt.v); err == nil {
+			t.Errorf("Decode(%s) into %T: expected an error", tt.in, tt.v)
+		}
+	}
+	if err := Decode("1", i); err == nil {
+		t.Error("Decode into a non-pointer: expected an error")
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{42, "42"},
+		{2.0, "2.00000"},
+		{1.0 / 3, "0.33333"},
+		{true, "true"},
+		{"a\"b\n", `"a\"b\n"`},
+		{byte('x'), `"x"`},
+		{[]int{1, 2, 3}, "[1,2,3]"},
+		{[]int{}, "[]"},
+		{[]int(nil), "[]"},
+		{[][]byte{{'a', 'b'}}, `[["a","b"]]`},
+		{[]string{"x", "y"}, `["x","y"]`},
+		{(*ListNode)(nil), "[]"},
+		{(*TreeNode)(nil), "[]"},
+		{nil, "null"},
+	}
+	for _, tt := range tests {
+		if got := Encode(tt.v); got != tt.want {
+			t.Errorf("Encode(%#v) = %s, want %s", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		in   string
+		vals []int
+	}{
+		{"[1,2,3]", []int{1, 2, 3}},
+		{"[5]", []int{5}},
+		{"[]", nil},
+		{"null", nil},
+	}
+	for _, tt := range tests {
+		var head *ListNode
+		if err := Decode(tt.in, &head); err != nil {
+			t.Errorf("Decode(%s): %v", tt.in, err)
+			continue
+		}
+		var vals []int
+		for n := head; n != nil; n = n.Next {
+			vals = append(vals, n.Val)
+		}
+		if !reflect.DeepEqual(vals, tt.vals) {
+			t.Errorf("Decode(%s) = %v, want %v", tt.in, vals, tt.vals)
+		}
+		if tt.in != "null" {
+			if got := Encode(head); got != tt.in {
+				t.Errorf("Encode(Decode(%s)) = %s", tt.in, got)
+			}
+		}
+	}
+
+	var head *ListNode
+	if err := Decode("[1,null]", &head); err == nil {
+		t.Error("Decode([1,null]) into *ListNode: expected an error")
+	}
+}
+
+func TestTree(t *testing.T) {
+	// 层序里的 null 只在有子节点的位置出现，编码回来应该和输入一样
+	for _, in := range []string{
+		"[]",
+		"[1]",
+		"[1,2,3]",
+		"[1,null,2,3]",
+		"[3,9,20,null,null,15,7]",
+		"[5,4,8,11,null,13,4,7,2,null,null,null,1]",
+	} {
+		var root *TreeNode
+		if err := Decode(in, &root); err != nil {
+			t.Errorf("Decode(%s): %v", in, err)
+			continue
+		}
+		if got := Encode(root); got != in {
+			t.Errorf("Encode(Decode(%s)) = %s", in, got)
+		}
+	}
+
+	var root *TreeNode
+	if err := Decode("[1,null,2,3]", &root); err != nil {
+		t.Fatal(err)
+	}
+	if root.Val != 1 || root.Left != nil || root.Right.Val != 2 || root.Right.Left.Val != 3 || root.Right.Right != nil {
+		t.Errorf("Decode([1,null,2,3]) built the wrong tree")
+	}
+	if err := Decode("[null,1]", &root); err != nil || root != nil {
+		t.Errorf("Decode([null,1]) = %v, %v; want nil tree", root, err)
+	}
+	if err := Decode(`[1,"a"]`, &root); err == nil {
+		t.Error(`Decode([1,"a"]) into *TreeNode: expected an error`)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	got, err := Split(`[[], [-2], [0, "a b"]]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"[]", "[-2]", `[0,"a b"]`}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Split = %q, want %q", got, want)
+	}
+	if _, err := Split("1"); err == nil {
+		t.Error("Split(1): expected an error")
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	tests := []struct {
+		in, typ string
+		want    string
+	}{
+		{"[1,2]", "[]int", "[]int{1, 2}"},
+		{"[[1],[]]", "[][]int", "[][]int{{1}, {}}"},
+		{`"a"`, "byte", "'a'"},
+		{"97", "byte", "97"},
+		{`"中"`, "rune", "'中'"},
+		{`"x"`, "int32", "'x'"},
+		{"-5", "int32", "-5"},
+		{"18446744073709551615", "uint64", "18446744073709551615"},
+		{"255", "uint8", "255"},
+		{"[1,null,2]", "*TreeNode", `ltgoNewTree[TreeNode]("[1,null,2]")`},
+		{"[1,2]", "*ListNode", `ltgoNewList[ListNode]("[1,2]")`},
+		{`"a\"b"`, "string", `"a\"b"`},
+		{"null", "[]int", "nil"},
+	}
+	for _, tt := range tests {
+		got, err := Literal(tt.in, tt.typ)
+		if err != nil {
+			t.Errorf("Literal(%s, %s): %v", tt.in, tt.typ, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Literal(%s, %s) = %s, want %s", tt.in, tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestLiteralError(t *testing.T) {
+	tests := []struct{ in, typ string }{
+		{`""`, "int32"},
+		{`"ab"`, "int32"},
+		{"-1", "uint"},
+		{"-1", "uint32"},
+		{"4294967296", "uint32"},
+		{"128", "int8"},
+		{"-1", "byte"},
+		{"256", "byte"},
+		{"1.5", "int"},
+		{`"1"`, "int"},
+		{"[1]", "map[int]int"},
+	}
+	for _, tt := range tests {
+		if got, err := Literal(tt.in, tt.typ); err == nil {
+			t.Errorf("Literal(%s, %s) = %s, expected an error", tt.in, tt.typ, got)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package codec
+
+import (
+	_ "embed"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+//go:embed codec.go
+var source string
+
+// Source 返回 codec.go 的源码，package 换成 pkg，用来和题解放在一起编译
+// 导出的函数和常量改成带 codec 前缀的名字 (Decode -> codecDecode)，免得和题解里的 Split、Equal 之类撞上；
+// ListNode / TreeNode 保留原名，题解可以直接用，omit 里的类型 (题解自己声明过的) 不再声明
+func Source(pkg string, omit ...string) string {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "codec.go", source, parser.ParseComments)
+	if err != nil {
+		panic("codec: embedded source: " + err.Error())
+	}
+	file.Name.Name = pkg
+
+	skip := map[string]bool{}
+	for _, name := range omit {
+		skip[name] = true
+	}
+	decls := file.Decls[:0]
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.TYPE {
+			specs := gen.Specs[:0]
+			for _, spec := range gen.Specs {
+				if !skip[spec.(*ast.TypeSpec).Name.Name] {
+					specs = append(specs, spec)
+				}
+			}
+			if gen.Specs = specs; len(specs) == 0 {
+				continue
+			}
+		}
+		decls = append(decls, decl)
+	}
+	file.Decls = decls
+	file.Comments = ast.NewCommentMap(fset, file, file.Comments).Filter(file).Comments()
+
+	// 只改包级别的导出名字 (Ident.Obj 指向包作用域)，结构体字段 Val / Next 不动
+	ast.Inspect(file, func(n ast.Node) bool {
+		ident, ok := n.(*ast.Ident)
+		if !ok || ident.Obj == nil || !ast.IsExported(ident.Name) || file.Scope.Lookup(ident.Name) != ident.Obj {
+			return true
+		}
+		if ident.Obj.Kind == ast.Fun || ident.Obj.Kind == ast.Con || ident.Obj.Kind == ast.Var {
+			ident.Name = "codec" + ident.Name
+		}
+		return true
+	})
+
+	var sb strings.Builder
+	if err := format.Node(&sb, fset, file); err != nil {
+		panic("codec: embedded source: " + err.Error())
+	}
+	return sb.String()
+}
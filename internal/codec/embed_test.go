@@ -0,0 +1,48 @@
+package codec
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestSource(t *testing.T) {
+	src := Source("main")
+	file, err := parser.ParseFile(token.NewFileSet(), "codec.go", src, 0)
+	if err != nil {
+		t.Fatalf("Source does not parse: %v", err)
+	}
+	if file.Name.Name != "main" {
+		t.Errorf("package = %s, want main", file.Name.Name)
+	}
+	for _, name := range []string{"Decode", "Encode", "Split", "Equal", "Validate", "Canonical", "FloatTolerance"} {
+		if file.Scope.Lookup(name) != nil {
+			t.Errorf("%s should be renamed", name)
+		}
+		if file.Scope.Lookup("codec"+name) == nil {
+			t.Errorf("codec%s is missing", name)
+		}
+	}
+	for _, name := range []string{"ListNode", "TreeNode"} {
+		if file.Scope.Lookup(name) == nil {
+			t.Errorf("%s is missing", name)
+		}
+	}
+	if !strings.Contains(src, "Next *ListNode") {
+		t.Error("struct fields should keep their names")
+	}
+}
+
+func TestSourceOmit(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "codec.go", Source("main", "ListNode"), 0)
+	if err != nil {
+		t.Fatalf("Source does not parse: %v", err)
+	}
+	if file.Scope.Lookup("ListNode") != nil {
+		t.Error("ListNode should be omitted")
+	}
+	if file.Scope.Lookup("TreeNode") == nil {
+		t.Error("TreeNode is missing")
+	}
+}
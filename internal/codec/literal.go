@@ -0,0 +1,120 @@
+package codec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Literal 把 LeetCode 文本转成 Go 源码里的字面量
+// typ 是 Go 类型表达式 (如 "[]int", "[][]byte", "*TreeNode")；
+// 链表和树没法写成字面量，输出 ltgoNewList[ListNode]("[1,2]") / ltgoNewTree[TreeNode]("[1,null,2]") 这样的调用
+// (辅助函数在生成的 ltgo_helpers_test.go 里)
+func Literal(s string, typ string) (string, error) {
+	n, err := codecParse(s)
+	if err != nil {
+		return "", err
+	}
+	return literal(n, strings.TrimSpace(typ), false)
+}
+
+// literal elide 为 true 时省略复合字面量的类型 (切片元素里的 {1, 2})
+func literal(n *codecNode, typ string, elide bool) (string, error) {
+	switch typ {
+	case "*ListNode", "*TreeNode":
+		if n.kind != '[' && n.kind != 'n' {
+			return "", fmt.Errorf("codec: cannot use %s as %s", codecKindName(n), typ)
+		}
+		var sb strings.Builder
+		codecWriteNode(&sb, n)
+		fn := "ltgoNewList[ListNode]"
+		if typ == "*TreeNode" {
+			fn = "ltgoNewTree[TreeNode]"
+		}
+		return fmt.Sprintf("%s(%q)", fn, sb.String()), nil
+	}
+
+	if elem, ok := strings.CutPrefix(typ, "[]"); ok {
+		if n.kind == 'n' {
+			return "nil", nil
+		}
+		if n.kind != '[' {
+			return "", fmt.Errorf("codec: cannot use %s as %s", codecKindName(n), typ)
+		}
+		items := make([]string, len(n.items))
+		for i, item := range n.items {
+			lit, err := literal(item, elem, true)
+			if err != nil {
+				return "", err
+			}
+			items[i] = lit
+		}
+		body := "{" + strings.Join(items, ", ") + "}"
+		if elide {
+			return body, nil
+		}
+		return typ + body, nil
+	}
+
+	switch typ {
+	case "int", "int64", "int32", "int16", "int8", "uint", "uint64", "uint32", "uint16":
+		if typ == "int32" && n.kind == 's' {
+			if len([]rune(n.text)) != 1 {
+				return "", fmt.Errorf("codec: %q is not a single character", n.text)
+			}
+			return strconv.QuoteRune([]rune(n.text)[0]), nil
+		}
+		if n.kind != '#' {
+			break
+		}
+		var err error
+		if unsigned, bits := codecIntBits(typ); unsigned {
+			_, err = strconv.ParseUint(n.text, 10, bits)
+		} else {
+			_, err = strconv.ParseInt(n.text, 10, bits)
+		}
+		if err != nil {
+			return "", fmt.Errorf("codec: %q is not a valid %s", n.text, typ)
+		}
+		return n.text, nil
+	case "rune":
+		if n.kind == 's' && len([]rune(n.text)) == 1 {
+			return strconv.QuoteRune([]rune(n.text)[0]), nil
+		}
+	case "byte", "uint8":
+		if n.kind == 's' && len(n.text) == 1 {
+			return strconv.QuoteRune(rune(n.text[0])), nil
+		}
+		if n.kind == '#' {
+			if _, err := strconv.ParseUint(n.text, 10, 8); err != nil {
+				return "", fmt.Errorf("codec: %q is not a valid %s", n.text, typ)
+			}
+			return n.text, nil
+		}
+	case "float64", "float32":
+		if n.kind == '#' {
+			return n.text, nil
+		}
+	case "bool":
+		if n.kind == 'b' {
+			return n.text, nil
+		}
+	case "string":
+		if n.kind == 's' {
+			return strconv.Quote(n.text), nil
+		}
+	default:
+		return "", fmt.Errorf("codec: unsupported type %s", typ)
+	}
+	return "", fmt.Errorf("codec: cannot use %s as %s", codecKindName(n), typ)
+}
+
+// codecIntBits 整数类型是否无符号、占几位 (int / uint 按 64 位)
+func codecIntBits(typ string) (unsigned bool, bits int) {
+	unsigned = strings.HasPrefix(typ, "u")
+	bits, err := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int"))
+	if err != nil {
+		bits = 64
+	}
+	return unsigned, bits
+}
@@ -2,61 +2,401 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
+// DefaultProfile 没有指定也没有设置默认 profile 时用的名字 (旧版单账号配置也迁移到这里)
+const DefaultProfile = "default"
+
+// Config 一个 profile (账号) 的配置，Load 返回的是当前生效的那一个
 type Config struct {
-	Cookie   string `json:"cookie"`
+	Cookie   string `json:"cookie,omitempty"` // 存在 credentials.json 里，见 secrets.go；config.json 里只有旧版本留下的
 	Language string `json:"language"`
 	Site     string `json:"site"`
+	CacheTTL string `json:"cache_ttl,omitempty"` // 题目详情缓存有效期，如 "72h"，为空用默认值
+	Retries  *int   `json:"retries,omitempty"`   // 被限流或服务端出错时的重试次数，为空用默认值
+	Wrap     *int   `json:"wrap,omitempty"`      // 题目描述注释的折行宽度，0 不折行，为空用默认值
+
+	DescriptionLanguage string `json:"description_language,omitempty"` // 题目描述的语言: en / zh / both，为空时有中文就用中文
+
+	Profile   string     `json:"-"` // 所属 profile 的名字，Save 时写回这里
+	Workspace *Workspace `json:"-"` // 当前目录所在的工作区 (.ltgo.json)，没有时为 nil
+
+	sources map[string]string // 被工作区、环境变量或命令行覆盖的字段 -> 来源，见 Source
+	applied *Config           // 覆盖后的值，Save 时用来判断字段有没有再被改过
 }
 
-func getConfigPath() (string, error) {
+// ErrNotInitialized 还没有任何 profile (没运行过 ltgo init)
+var ErrNotInitialized = errors.New("no profiles configured, run 'ltgo init' first")
+
+// Overrides 命令行全局参数 (由 root 命令设置)，Load 时优先于配置文件
+var Overrides struct {
+	Profile    string // --profile
+	ConfigPath string // --config
+	Site       string // --site
+	Language   string // --lang
+	CookieFile string // --cookie-file
+}
+
+// 环境变量，优先级在命令行参数和配置文件之间
+const (
+	EnvConfig   = "LTGO_CONFIG"
+	EnvSite     = "LTGO_SITE"
+	EnvLanguage = "LTGO_LANG"
+	EnvCookie   = "LTGO_COOKIE"
+)
+
+// File 配置文件 (~/.ltgo/config.json) 的完整内容
+type File struct {
+	Default  string             `json:"default_profile,omitempty"`
+	Profiles map[string]*Config `json:"profiles"`
+}
+
+// Dir 返回 ltgo 的数据目录 (~/.ltgo)，索引、缓存等文件都放在这里
+func Dir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, ".ltgo", "config.json"), nil
+	return filepath.Join(home, ".ltgo"), nil
 }
 
+// getConfigPath --config > $LTGO_CONFIG > ~/.ltgo/config.json
+func getConfigPath() (string, error) {
+	if Overrides.ConfigPath != "" {
+		return Overrides.ConfigPath, nil
+	}
+	if path := os.Getenv(EnvConfig); path != "" {
+		return path, nil
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// Load 读取当前生效的 profile: --profile > 配置文件里的默认 profile
+// site、language、cookie 的优先级: 命令行参数 > 环境变量 > 工作区 (.ltgo.json) > profile > 默认值
+// 通过参数或环境变量给了 Cookie 时，没有配置文件也能用 (CI 里不用先 ltgo init)
 func Load() (*Config, error) {
-	home, _ := os.UserHomeDir()
-	configPath := filepath.Join(home, ".ltgo", "config.json") // 保持 config.json
+	f, err := LoadFile()
+	if err != nil {
+		return nil, err
+	}
+
+	name := f.Active()
+	cfg := &Config{}
+	if p, ok := f.Profiles[name]; ok {
+		*cfg = *p
+	} else if len(f.Profiles) > 0 || Overrides.Profile != "" {
+		return nil, fmt.Errorf("profile '%s' not found (see 'ltgo profile list')", name)
+	}
+	cfg.Profile = name
 
-	data, err := os.ReadFile(configPath)
+	cwd, err := os.Getwd()
 	if err != nil {
 		return nil, err
 	}
+	if cfg.Workspace, err = FindWorkspace(cwd); err != nil {
+		return nil, err
+	}
 
-	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
+	if err := cfg.applyOverrides(); err != nil {
 		return nil, err
 	}
+	if _, ok := f.Profiles[name]; ok && cfg.sources["cookie"] == "" {
+		s, err := loadSecrets()
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Cookie, err = s.cookie(name); err != nil {
+			return nil, err
+		}
+	}
+	if len(f.Profiles) == 0 && cfg.Cookie == "" {
+		return nil, ErrNotInitialized
+	}
 
 	// 设置默认语言 (兼容旧配置文件)
 	if cfg.Language == "" {
 		cfg.Language = "golang"
 	}
 
-	return &cfg, nil
+	return cfg, nil
 }
 
-func (c *Config) Save() error {
+// applyOverrides 用工作区、环境变量和命令行参数覆盖 profile 里的值，并记下来源
+func (c *Config) applyOverrides() error {
+	c.sources = map[string]string{}
+	set := func(key string, field *string, value, source string) {
+		if value != "" {
+			*field = value
+			c.sources[key] = source
+		}
+	}
+
+	if ws := c.Workspace; ws != nil {
+		set("language", &c.Language, ws.Language, "workspace "+ws.Path)
+	}
+	set("site", &c.Site, os.Getenv(EnvSite), "env "+EnvSite)
+	set("language", &c.Language, os.Getenv(EnvLanguage), "env "+EnvLanguage)
+	set("cookie", &c.Cookie, strings.TrimSpace(os.Getenv(EnvCookie)), "env "+EnvCookie)
+
+	set("site", &c.Site, Overrides.Site, "flag --site")
+	set("language", &c.Language, Overrides.Language, "flag --lang")
+	if Overrides.CookieFile != "" {
+		data, err := os.ReadFile(Overrides.CookieFile)
+		if err != nil {
+			return fmt.Errorf("failed to read cookie file: %w", err)
+		}
+		cookie := strings.TrimSpace(string(data))
+		if cookie == "" {
+			return fmt.Errorf("cookie file %s is empty", Overrides.CookieFile)
+		}
+		set("cookie", &c.Cookie, cookie, "flag --cookie-file")
+	}
+
+	if src, ok := c.sources["site"]; ok && c.Site != "cn" && c.Site != "com" {
+		return fmt.Errorf("invalid site '%s' from %s: must be 'cn' or 'com'", c.Site, src)
+	}
+	c.applied = &Config{Site: c.Site, Language: c.Language, Cookie: c.Cookie}
+	return nil
+}
+
+// Source 字段当前值的来源，如 "flag --site"、"env LTGO_SITE"、"workspace /repo/.ltgo.json"、"profile 'default'"、"default"
+// key 是 site、language、cookie，以及只能在工作区里设置的 output_dir、layout、template
+func (c *Config) Source(key string) string {
+	if src := c.sources[key]; src != "" {
+		return src
+	}
+
+	fromWorkspace := false
+	switch ws := c.Workspace; key {
+	case "output_dir":
+		fromWorkspace = ws != nil && ws.OutputDir != ""
+	case "layout":
+		fromWorkspace = ws != nil && ws.Layout != ""
+	case "template":
+		fromWorkspace = ws != nil && ws.Template != ""
+	case "language":
+		if c.applied != nil && c.applied.Language == "" {
+			return "default"
+		}
+		return fmt.Sprintf("profile '%s'", c.Profile)
+	default:
+		return fmt.Sprintf("profile '%s'", c.Profile)
+	}
+	if fromWorkspace {
+		return "workspace " + c.Workspace.Path
+	}
+	return "default"
+}
+
+// LoadFile 读取整个配置文件，文件不存在时返回空的 File
+// 旧版的单账号配置 ({"cookie": ..., "site": ...}) 会被当成名为 default 的 profile
+func LoadFile() (*File, error) {
+	path, err := getConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	f := &File{Profiles: map[string]*Config{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, err
+	}
+	if f.Profiles == nil {
+		f.Profiles = map[string]*Config{}
+	}
+
+	if len(f.Profiles) == 0 {
+		var legacy Config
+		if err := json.Unmarshal(data, &legacy); err == nil && (legacy.Cookie != "" || legacy.Site != "") {
+			f.Profiles[DefaultProfile] = &legacy
+			f.Default = DefaultProfile
+		}
+	}
+	migrate := false
+	for name, p := range f.Profiles {
+		p.Profile = name
+		migrate = migrate || p.Cookie != ""
+	}
+
+	// 旧版本把 Cookie 明文写在 config.json 里，挪到 credentials.json
+	if migrate {
+		if err := f.Save(); err != nil {
+			return nil, fmt.Errorf("failed to move cookies out of %s: %w", path, err)
+		}
+		for _, p := range f.Profiles {
+			p.Cookie = ""
+		}
+	}
+	return f, nil
+}
+
+// Active 当前生效的 profile 名
+func (f *File) Active() string {
+	switch {
+	case Overrides.Profile != "":
+		return Overrides.Profile
+	case f.Default != "":
+		return f.Default
+	}
+	return DefaultProfile
+}
+
+// Names 所有 profile 名，按字母排序
+func (f *File) Names() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Save 写回配置文件
+// Cookie 不进 config.json：非空的写到 credentials.json，删掉的 profile 的 Cookie 也一起删掉
+func (f *File) Save() error {
 	path, err := getConfigPath()
 	if err != nil {
 		return err
 	}
 
+	s, err := loadSecrets()
+	if err != nil {
+		return err
+	}
+	out := &File{Default: f.Default, Profiles: make(map[string]*Config, len(f.Profiles))}
+	for name, p := range f.Profiles {
+		if p.Cookie != "" {
+			if err := s.setCookie(name, p.Cookie); err != nil {
+				return err
+			}
+		}
+		stripped := *p
+		stripped.Cookie = ""
+		out.Profiles[name] = &stripped
+	}
+	for name := range s.Cookies {
+		if _, ok := f.Profiles[name]; !ok {
+			delete(s.Cookies, name)
+		}
+	}
+	// 先写 Cookie 再写配置，迁移时中途失败也不会丢
+	if err := s.save(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(out, "", " ")
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data, 0644)
+}
+
+// writeFile 先写临时文件再改名，写到一半被打断也不会把文件弄坏
+// 权限是 0600 这类只有自己可读的文件时，新建的目录也只有自己可进
+func writeFile(path string, data []byte, perm os.FileMode) error {
 	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	dirPerm := os.FileMode(0755)
+	if perm&0077 == 0 {
+		dirPerm = 0700
+	}
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
 		return err
 	}
 
-	data, err := json.MarshalIndent(c, "", " ")
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
 	if err != nil {
 		return err
 	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Save 把这个 profile 写回配置文件 (其他 profile 不动)
+// 新建的 Config 没有 Profile 时存到当前生效的 profile；文件里还没有默认 profile 时把它设成默认
+// 来自工作区、命令行参数或环境变量、之后又没改过的字段不会写进文件，保留 profile 原来的值
+func (c *Config) Save() error {
+	f, err := LoadFile()
+	if err != nil {
+		return err
+	}
+	if c.Profile == "" {
+		c.Profile = f.Active()
+	}
+
+	out := *c
+	stored := f.Profiles[c.Profile]
+	if stored == nil {
+		stored = &Config{}
+	}
+	// 不是 Load 得到的 Config (ltgo init、profile add 新建的) 没有被覆盖的字段
+	if c.applied != nil {
+		keep := func(key string, field *string, applied, old string) {
+			if c.sources[key] != "" && *field == applied {
+				*field = old
+			}
+		}
+		keep("site", &out.Site, c.applied.Site, stored.Site)
+		keep("language", &out.Language, c.applied.Language, stored.Language)
+		keep("cookie", &out.Cookie, c.applied.Cookie, stored.Cookie)
+	}
 
-	return os.WriteFile(path, data, 0644)
+	f.Profiles[c.Profile] = &out
+	if f.Default == "" {
+		f.Default = c.Profile
+	}
+	return f.Save()
+}
+
+// SaveCookie 只更新这个 profile 的 Cookie (credentials.json)，配置文件不动
+// Cookie 来自命令行参数或环境变量时不写文件
+func (c *Config) SaveCookie(cookie string) error {
+	if c.sources["cookie"] != "" {
+		return nil
+	}
+	f, err := LoadFile()
+	if err != nil {
+		return err
+	}
+	if _, ok := f.Profiles[c.Profile]; !ok {
+		return nil
+	}
+	s, err := loadSecrets()
+	if err != nil {
+		return err
+	}
+	if old, err := s.cookie(c.Profile); err == nil && old == cookie {
+		return nil
+	}
+	if err := s.setCookie(c.Profile, cookie); err != nil {
+		return err
+	}
+	return s.save()
 }
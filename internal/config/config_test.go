@@ -0,0 +1,63 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// setup 把配置文件放到临时目录，清掉会覆盖配置的环境变量
+func setup(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv(EnvConfig, filepath.Join(dir, "config.json"))
+	for _, env := range []string{EnvSite, EnvLanguage, EnvCookie, EnvPassphrase} {
+		t.Setenv(env, "")
+	}
+	t.Chdir(dir)
+	Overrides.Profile, Overrides.ConfigPath, Overrides.Site, Overrides.Language, Overrides.CookieFile = "", "", "", "", ""
+}
+
+// ltgo init / profile add 新建的 Config 不是 Load 得到的，Save 不能依赖 Load 留下的状态
+func TestSaveFreshConfig(t *testing.T) {
+	setup(t)
+
+	if _, err := Load(); err != ErrNotInitialized {
+		t.Fatalf("Load before init: got %v, want ErrNotInitialized", err)
+	}
+
+	fresh := &Config{Site: "cn", Cookie: "LEETCODE_SESSION=abc; csrftoken=def", Language: "golang"}
+	if err := fresh.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load after Save: %v", err)
+	}
+	if cfg.Site != "cn" || cfg.Language != "golang" || cfg.Cookie != fresh.Cookie {
+		t.Errorf("Load after Save = %+v", cfg)
+	}
+}
+
+func TestSaveNewProfile(t *testing.T) {
+	setup(t)
+
+	if err := (&Config{Site: "cn", Cookie: "a=1", Language: "golang"}).Save(); err != nil {
+		t.Fatalf("Save default: %v", err)
+	}
+	work := &Config{Profile: "work", Site: "com", Cookie: "b=2", Language: "python3"}
+	if err := work.Save(); err != nil {
+		t.Fatalf("Save new profile: %v", err)
+	}
+
+	Overrides.Profile = "work"
+	defer func() { Overrides.Profile = "" }()
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load work: %v", err)
+	}
+	if cfg.Site != "com" || cfg.Cookie != "b=2" || cfg.Language != "python3" {
+		t.Errorf("Load work = %+v", cfg)
+	}
+}
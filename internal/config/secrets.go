@@ -0,0 +1,377 @@
+package config
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Cookie 不写在 config.json 里，单独存在同目录的 credentials.json (权限 0600)
+// 设置了口令时 Cookie 用 AES-256-GCM 加密，密钥由口令经 PBKDF2-SHA256 得到
+// ltgo unlock 之后密钥缓存在 $XDG_RUNTIME_DIR 下 (登录会话结束即清空)，有效期内不用再输口令
+// 没有私有的运行时目录时不缓存，每条命令自己问口令
+const credentialsName = "credentials.json"
+
+// EnvPassphrase 口令也可以放在这个环境变量里 (CI 等没有终端的场合)
+const EnvPassphrase = "LTGO_PASSPHRASE"
+
+// DefaultUnlockTTL ltgo unlock 默认保持解锁的时间
+const DefaultUnlockTTL = 8 * time.Hour
+
+const kdfIterations = 600000
+
+var (
+	// ErrLocked Cookie 已加密，但没有解锁也没法输入口令
+	ErrLocked = errors.New("credentials are encrypted, run 'ltgo unlock' first")
+	// ErrBadPassphrase 口令不对
+	ErrBadPassphrase = errors.New("wrong passphrase")
+	// ErrNoSessionDir 没有只属于当前用户的 $XDG_RUNTIME_DIR，没法缓存解锁的密钥
+	ErrNoSessionDir = errors.New("$XDG_RUNTIME_DIR is not set or not private to this user")
+)
+
+// Passphrase 需要口令又没有解锁时调用 (由命令行设置成从终端读取)，为 nil 时直接返回 ErrLocked
+var Passphrase func(prompt string) (string, error)
+
+// unlocked 本进程里已经得到的密钥，避免同一条命令里重复输入口令
+var unlocked []byte
+
+// secrets credentials.json 的内容
+type secrets struct {
+	Encryption *encryption       `json:"encryption,omitempty"`
+	Cookies    map[string]string `json:"cookies"` // profile -> Cookie，加密时是 base64(nonce + 密文)
+}
+
+type encryption struct {
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Check      []byte `json:"check"` // 加密后的固定字符串，用来判断口令对不对
+}
+
+const checkPlain = "ltgo"
+
+func credentialsPath() (string, error) {
+	path, err := getConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), credentialsName), nil
+}
+
+func loadSecrets() (*secrets, error) {
+	path, err := credentialsPath()
+	if err != nil {
+		return nil, err
+	}
+	s := &secrets{Cookies: map[string]string{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	if s.Cookies == nil {
+		s.Cookies = map[string]string{}
+	}
+	return s, nil
+}
+
+func (s *secrets) save() error {
+	path, err := credentialsPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", " ")
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data, 0600)
+}
+
+// cookie 读出 profile 的 Cookie，加密时先解锁
+func (s *secrets) cookie(profile string) (string, error) {
+	stored, ok := s.Cookies[profile]
+	if !ok || s.Encryption == nil {
+		return stored, nil
+	}
+	key, err := s.key()
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(stored)
+	if err != nil {
+		return "", fmt.Errorf("cookie of profile '%s' is corrupted", profile)
+	}
+	plain, err := unseal(key, data)
+	if err != nil {
+		return "", fmt.Errorf("cookie of profile '%s' is corrupted", profile)
+	}
+	return string(plain), nil
+}
+
+// setCookie 写入 profile 的 Cookie，加密时先解锁
+func (s *secrets) setCookie(profile, cookie string) error {
+	if s.Encryption == nil {
+		s.Cookies[profile] = cookie
+		return nil
+	}
+	key, err := s.key()
+	if err != nil {
+		return err
+	}
+	sealed, err := seal(key, []byte(cookie))
+	if err != nil {
+		return err
+	}
+	s.Cookies[profile] = base64.StdEncoding.EncodeToString(sealed)
+	return nil
+}
+
+// key 加密用的密钥: 本进程已解锁 > 会话缓存 > $LTGO_PASSPHRASE > 向用户要口令
+func (s *secrets) key() ([]byte, error) {
+	if s.verify(unlocked) {
+		return unlocked, nil
+	}
+	if key, ok := s.cachedKey(); ok {
+		unlocked = key
+		return key, nil
+	}
+
+	var pass string
+	switch {
+	case os.Getenv(EnvPassphrase) != "":
+		pass = os.Getenv(EnvPassphrase)
+	case Passphrase != nil:
+		var err error
+		if pass, err = Passphrase("Passphrase: "); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrLocked
+	}
+
+	key := s.derive(pass)
+	if !s.verify(key) {
+		return nil, ErrBadPassphrase
+	}
+	// 只在本进程里记住，缓存到会话要显式 ltgo unlock
+	unlocked = key
+	return key, nil
+}
+
+func (s *secrets) derive(pass string) []byte {
+	key, _ := pbkdf2.Key(sha256.New, pass, s.Encryption.Salt, s.Encryption.Iterations, 32)
+	return key
+}
+
+func (s *secrets) verify(key []byte) bool {
+	if key == nil {
+		return false
+	}
+	plain, err := unseal(key, s.Encryption.Check)
+	return err == nil && string(plain) == checkPlain
+}
+
+// encrypt 换成新口令加密 (pass 为空表示去掉加密)，调用前所有 Cookie 必须是能读出来的
+func (s *secrets) encrypt(pass string) error {
+	if s.Encryption != nil {
+		if _, err := s.key(); err != nil {
+			return err
+		}
+	}
+	plain := map[string]string{}
+	for name := range s.Cookies {
+		cookie, err := s.cookie(name)
+		if err != nil {
+			return err
+		}
+		plain[name] = cookie
+	}
+
+	s.Encryption = nil
+	unlocked = nil
+	if pass != "" {
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+		s.Encryption = &encryption{KDF: "pbkdf2-sha256", Iterations: kdfIterations, Salt: salt}
+		unlocked = s.derive(pass)
+		check, err := seal(unlocked, []byte(checkPlain))
+		if err != nil {
+			return err
+		}
+		s.Encryption.Check = check
+	}
+
+	for name, cookie := range plain {
+		if err := s.setCookie(name, cookie); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func seal(key, plain []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plain, nil), nil
+}
+
+func unseal(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	return gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// session 解锁后缓存的密钥
+type session struct {
+	Key     []byte    `json:"key"`
+	Salt    []byte    `json:"salt"` // 换了口令 (salt 变了) 缓存自动失效
+	Expires time.Time `json:"expires"`
+}
+
+// sessionPath 会话缓存文件，每个 credentials.json 一个
+// 只放在 $XDG_RUNTIME_DIR (注销或重启后清空)，而且这个目录必须属于当前用户、权限 0700，
+// 否则返回 ErrNoSessionDir，不退回到 /tmp 这种别人也能放东西的地方
+func sessionPath() (string, error) {
+	creds, err := credentialsPath()
+	if err != nil {
+		return "", err
+	}
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" || !filepath.IsAbs(dir) {
+		return "", ErrNoSessionDir
+	}
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() || fi.Mode().Perm() != 0700 || !ownedByUser(fi) {
+		return "", ErrNoSessionDir
+	}
+	sum := sha256.Sum256([]byte(creds))
+	return filepath.Join(dir, "ltgo", "session-"+hex.EncodeToString(sum[:6])+".json"), nil
+}
+
+func (s *secrets) cachedKey() ([]byte, bool) {
+	path, err := sessionPath()
+	if err != nil {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var sess session
+	if json.Unmarshal(data, &sess) != nil || time.Now().After(sess.Expires) || !bytes.Equal(sess.Salt, s.Encryption.Salt) {
+		return nil, false
+	}
+	return sess.Key, s.verify(sess.Key)
+}
+
+func (s *secrets) cacheKey(key []byte, ttl time.Duration) error {
+	path, err := sessionPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(session{Key: key, Salt: s.Encryption.Salt, Expires: time.Now().Add(ttl)})
+	if err != nil {
+		return err
+	}
+	return writeFile(path, data, 0600)
+}
+
+// Encrypted Cookie 是否设置了口令加密
+func Encrypted() (bool, error) {
+	s, err := loadSecrets()
+	if err != nil {
+		return false, err
+	}
+	return s.Encryption != nil, nil
+}
+
+// SetPassphrase 用新口令加密所有 Cookie (已经加密时先用旧口令解锁)，pass 为空时改回不加密
+// 之前缓存的密钥随之作废，要用 ltgo unlock 重新解锁
+func SetPassphrase(pass string) error {
+	// 先把旧版 config.json 里的明文 Cookie 挪过来
+	if _, err := LoadFile(); err != nil {
+		return err
+	}
+	s, err := loadSecrets()
+	if err != nil {
+		return err
+	}
+	if err := s.encrypt(pass); err != nil {
+		return err
+	}
+	if err := s.save(); err != nil {
+		return err
+	}
+	return Lock()
+}
+
+// Unlock 校验口令并把密钥缓存 ttl 这么久，期间其他命令不用再输口令
+// 没有私有的 $XDG_RUNTIME_DIR 时返回 ErrNoSessionDir，什么都不缓存
+func Unlock(pass string, ttl time.Duration) error {
+	s, err := loadSecrets()
+	if err != nil {
+		return err
+	}
+	if s.Encryption == nil {
+		return errors.New("credentials are not encrypted (see 'ltgo config encrypt')")
+	}
+	key := s.derive(pass)
+	if !s.verify(key) {
+		return ErrBadPassphrase
+	}
+	unlocked = key
+	return s.cacheKey(key, ttl)
+}
+
+// Lock 删掉会话里缓存的密钥
+func Lock() error {
+	unlocked = nil
+	path, err := sessionPath()
+	if errors.Is(err, ErrNoSessionDir) {
+		// 没有地方缓存，也就没有要删的
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
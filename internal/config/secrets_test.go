@@ -0,0 +1,105 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// encrypted 保存一个带 Cookie 的配置并用 pass 加密，之后本进程里是锁着的
+func encrypted(t *testing.T, pass string) {
+	t.Helper()
+	if err := (&Config{Site: "cn", Cookie: "a=1", Language: "golang"}).Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	Passphrase = nil
+	if err := SetPassphrase(pass); err != nil {
+		t.Fatalf("SetPassphrase: %v", err)
+	}
+	t.Cleanup(func() { Passphrase, unlocked = nil, nil })
+}
+
+func runtimeDir(t *testing.T, perm os.FileMode) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "run")
+	if err := os.Mkdir(dir, perm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(dir, perm); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_RUNTIME_DIR", dir)
+	return dir
+}
+
+func TestUnlockNeedsPrivateRuntimeDir(t *testing.T) {
+	setup(t)
+	encrypted(t, "secret")
+
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	if err := Unlock("secret", DefaultUnlockTTL); !errors.Is(err, ErrNoSessionDir) {
+		t.Errorf("Unlock without XDG_RUNTIME_DIR: got %v, want ErrNoSessionDir", err)
+	}
+
+	dir := runtimeDir(t, 0755)
+	if err := Unlock("secret", DefaultUnlockTTL); !errors.Is(err, ErrNoSessionDir) {
+		t.Errorf("Unlock with a 0755 runtime dir: got %v, want ErrNoSessionDir", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("something was cached in a shared dir: %v", entries)
+	}
+}
+
+func TestUnlockCachesKey(t *testing.T) {
+	setup(t)
+	encrypted(t, "secret")
+	runtimeDir(t, 0700)
+
+	if err := Unlock("wrong", DefaultUnlockTTL); !errors.Is(err, ErrBadPassphrase) {
+		t.Fatalf("Unlock with a wrong passphrase: got %v", err)
+	}
+	if err := Unlock("secret", DefaultUnlockTTL); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+
+	// 新进程: 不问口令也能读到 Cookie
+	unlocked = nil
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load after unlock: %v", err)
+	}
+	if cfg.Cookie != "a=1" {
+		t.Errorf("Cookie = %q", cfg.Cookie)
+	}
+
+	if err := Lock(); err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	if _, err := Load(); !errors.Is(err, ErrLocked) {
+		t.Errorf("Load after lock: got %v, want ErrLocked", err)
+	}
+}
+
+// 命令里输入口令只解锁这一条命令，不缓存到会话
+func TestPromptDoesNotCache(t *testing.T) {
+	setup(t)
+	encrypted(t, "secret")
+	dir := runtimeDir(t, 0700)
+
+	asked := 0
+	Passphrase = func(string) (string, error) {
+		asked++
+		return "secret", nil
+	}
+	unlocked = nil
+	if _, err := Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if asked != 1 {
+		t.Errorf("asked for the passphrase %d times", asked)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("prompting cached the key: %v", entries)
+	}
+}
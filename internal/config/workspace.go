@@ -0,0 +1,95 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WorkspaceFile 工作区配置文件名，从当前目录往上找到的第一个生效
+const WorkspaceFile = ".ltgo.json"
+
+// DefaultOutputDir 没有工作区或工作区没有设置 output_dir 时的题解目录
+const DefaultOutputDir = "questions"
+
+// Workspace 一个练习仓库自己的约定 (.ltgo.json)，优先于全局配置
+type Workspace struct {
+	Path      string `json:"-"`                    // .ltgo.json 的路径
+	OutputDir string `json:"output_dir,omitempty"` // 题解目录，相对于 .ltgo.json 所在目录
+	Language  string `json:"language,omitempty"`
+	Layout    string `json:"layout,omitempty"`   // 文件名模板，如 "{slug}/main.{ext}"，见 generator.FileName
+	Template  string `json:"template,omitempty"` // 题解文件模板 (text/template)，相对于 .ltgo.json 所在目录
+	Readme    bool   `json:"readme,omitempty"`   // gen / daily 总是生成 Markdown 题面 (同 --readme)
+}
+
+// FindWorkspace 从 dir 开始往上找 .ltgo.json，找不到返回 nil
+func FindWorkspace(dir string) (*Workspace, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		path := filepath.Join(dir, WorkspaceFile)
+		data, err := os.ReadFile(path)
+		if err == nil {
+			ws := &Workspace{Path: path}
+			if err := json.Unmarshal(data, ws); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", path, err)
+			}
+			return ws, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// Root .ltgo.json 所在的目录
+func (w *Workspace) Root() string {
+	return filepath.Dir(w.Path)
+}
+
+// resolve 把工作区里的相对路径转成绝对路径
+func (w *Workspace) resolve(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(w.Root(), path)
+}
+
+// OutputDir 生成题解的目录: 工作区的 output_dir (相对于 .ltgo.json)，否则是 <当前目录>/questions
+func (c *Config) OutputDir() string {
+	if ws := c.Workspace; ws != nil {
+		dir := ws.OutputDir
+		if dir == "" {
+			dir = DefaultOutputDir
+		}
+		return ws.resolve(dir)
+	}
+	cwd, _ := os.Getwd()
+	return filepath.Join(cwd, DefaultOutputDir)
+}
+
+// Layout 题解文件名模板，为空用 generator 的默认格式
+func (c *Config) Layout() string {
+	if c.Workspace == nil {
+		return ""
+	}
+	return c.Workspace.Layout
+}
+
+// Template 题解文件模板的路径，为空用内置格式
+func (c *Config) Template() string {
+	if c.Workspace == nil {
+		return ""
+	}
+	return c.Workspace.resolve(c.Workspace.Template)
+}
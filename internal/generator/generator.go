@@ -1,37 +1,52 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/X-for/ltgo/internal/models"
+	"github.com/X-for/ltgo/internal/render"
 )
 
-// getBestContent default Chinese, then English
-func getBestContent(q *models.QuestionDetail) string {
-	if q.TranslatedContent != "" {
-		return q.TranslatedContent
-	}
-	return q.Content
+// Options 生成题解文件的选项
+type Options struct {
+	Site     string // "cn" 或 "com"
+	Lang     string // 目标语言 slug (e.g. "golang", "python3")
+	Layout   string // 文件名模板，为空用 "{id}_{slug}.{ext}"，见 FileName
+	Template string // 题解文件模板的路径，为空用内置格式，见 renderTemplate
+	Wrap     int    // 描述注释的折行宽度，<= 0 不折行 (配置里没写时调用方传 DefaultWrap)
+	DescLang string // 描述的语言: en / zh / both，为空时有中文就用中文，见 render.Description
+
+	Readme   bool                             // 另外生成 Markdown 题面，见 WriteReadme
+	Download func(url string) ([]byte, error) // 下载题面里的图片，为 nil 时图片保留原链接
 }
 
 // Generate 生成题目文件到指定目录
 // q: 题目详情
 // outputDir: 输出目录
-// site: "cn" 或 "com"
-// lang: 目标语言 slug (e.g. "golang", "python3")
-func Generate(q *models.QuestionDetail, outputDir string, site string, lang string) error {
+func Generate(q *models.QuestionDetail, outputDir string, opts Options) error {
+	site, lang := opts.Site, opts.Lang
+
 	// 1. 获取语言配置
 	langConf := GetLangConfig(lang)
 
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+	// 2. 构造文件名 (使用正确的后缀)，layout 里可以带子目录
+	filename, err := FileName(opts.Layout, q, langConf)
+	if err != nil {
+		return err
+	}
+	// 设计类题目都有 Constructor，和别的题放在同一个目录里 go test 会重复声明，单独放一个子目录
+	if lang == "golang" && !ownDir(opts.Layout) && isDesign(q) {
+		base := filepath.Base(filename)
+		filename = filepath.Join(filepath.Dir(filename), strings.TrimSuffix(base, filepath.Ext(base)), base)
 	}
-
-	// 2. 构造文件名 (使用正确的后缀)
-	filename := fmt.Sprintf("%s_%s.%s", q.QuestionFrontendID, q.TitleSlug, langConf.Extension)
 	fullPath := filepath.Join(outputDir, filename)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
 
 	// 3. 提取对应语言的代码 Snippet
 	var code string
@@ -49,11 +64,8 @@ func Generate(q *models.QuestionDetail, outputDir string, site string, lang stri
 	}
 
 	// 4. 准备注释内容
-	descHTML := q.Content
-	if q.TranslatedContent != "" {
-		descHTML = q.TranslatedContent
-	}
-	descText := htmlToText(descHTML)
+	descText := htmlToText(render.Description(q, opts.DescLang), opts.Wrap)
+	title := render.Title(q, opts.DescLang)
 
 	// 格式化注释 (根据语言风格)
 	var descComment string
@@ -86,7 +98,7 @@ func Generate(q *models.QuestionDetail, outputDir string, site string, lang stri
  * Difficulty: %s
  *
 %s
- */`, q.QuestionFrontendID, q.Title, q.Difficulty, commentBody)
+ */`, q.QuestionFrontendID, title, q.Difficulty, commentBody)
 
 	} else {
 		// Script-style (Python, Ruby, Shell)
@@ -99,15 +111,31 @@ func Generate(q *models.QuestionDetail, outputDir string, site string, lang stri
 %s Title: %s
 %s Difficulty: %s
 %s
-%s`, langConf.Comment, q.QuestionFrontendID, langConf.Comment, q.Title, langConf.Comment, q.Difficulty, langConf.Comment, FormatComment(descText, langConf))
+%s`, langConf.Comment, q.QuestionFrontendID, langConf.Comment, title, langConf.Comment, q.Difficulty, langConf.Comment, FormatComment(descText, langConf))
 	}
 
 	// 5. 拼接完整文件内容
 	var fileContent string
 
-	if lang == "golang" {
-		// Go 特殊处理: 需要 package main 和 import
-		fileContent = fmt.Sprintf("package main\n\nimport \"fmt\"\n\n%s\n\n%s\n\n%s\n", metaBlock, descComment, wrappedCode)
+	if opts.Template != "" {
+		fileContent, err = renderTemplate(opts.Template, templateData{
+			ID:          q.QuestionFrontendID,
+			Title:       title,
+			Slug:        q.TitleSlug,
+			Difficulty:  q.Difficulty,
+			Lang:        lang,
+			Site:        appName,
+			Meta:        metaBlock,
+			Description: descComment,
+			Code:        wrappedCode,
+		})
+		if err != nil {
+			return err
+		}
+	} else if lang == "golang" {
+		// Go 特殊处理: 需要 package main
+		// 不写 import，ltgo test 会按题解用到的包自动补上
+		fileContent = fmt.Sprintf("package main\n\n%s\n\n%s\n\n%s\n", metaBlock, descComment, wrappedCode)
 	} else {
 		// 其他语言直接拼接
 		fileContent = fmt.Sprintf("%s\n\n%s\n\n%s\n", metaBlock, descComment, wrappedCode)
@@ -120,5 +148,27 @@ func Generate(q *models.QuestionDetail, outputDir string, site string, lang stri
 
 	// 7. 写入文件
 	fmt.Printf("Generating file: %s\n", fullPath)
-	return os.WriteFile(fullPath, []byte(fileContent), 0644)
+	if err := os.WriteFile(fullPath, []byte(fileContent), 0644); err != nil {
+		return err
+	}
+
+	// 8. Markdown 题面，失败不影响题解文件
+	if opts.Readme {
+		if err := WriteReadme(q, ReadmePath(fullPath, opts.Layout), opts.DescLang, opts.Download); err != nil {
+			fmt.Printf("Skipping README: %v\n", err)
+		}
+	}
+
+	// 9. Go 额外生成表驱动测试，失败不影响题解文件
+	if lang == "golang" {
+		testPath := strings.TrimSuffix(fullPath, ".go") + "_test.go"
+		if err := GenerateTest(q, testPath); err != nil {
+			if errors.Is(err, errNoExamples) {
+				fmt.Println("No examples found in the description, skipping test file.")
+			} else {
+				fmt.Printf("Skipping test file: %v\n", err)
+			}
+		}
+	}
+	return nil
 }
@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/X-for/ltgo/internal/models"
+)
+
+func minStack() *models.QuestionDetail {
+	return &models.QuestionDetail{
+		QuestionFrontendID: "155",
+		TitleSlug:          "min-stack",
+		Title:              "Min Stack",
+		Difficulty:         "Medium",
+		Content:            "<p>Design a stack.</p>",
+		MetaData:           `{"classname":"MinStack","constructor":{"params":[]},"methods":[{"name":"push","params":[{"type":"integer","name":"val"}],"return":{"type":"void"}}],"systemdesign":true}`,
+		CodeSnippets:       []models.CodeSnippet{{LangSlug: "golang", Code: "type MinStack struct {\n\n}\n\nfunc Constructor() MinStack {\n\n}\n\nfunc (this *MinStack) Push(val int) {\n\n}"}},
+	}
+}
+
+// 设计类题目都有 Constructor，平铺的 layout 下要单独放一个目录
+func TestGenerateDesignOwnDir(t *testing.T) {
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{"", "155_min-stack/155_min-stack.go"},
+		{"{difficulty}/{id4}-{slug}.{ext}", "medium/0155-min-stack/0155-min-stack.go"},
+		{"{slug}/main.{ext}", "min-stack/main.go"},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		if err := Generate(minStack(), dir, Options{Site: "com", Lang: "golang", Layout: tt.layout}); err != nil {
+			t.Fatalf("Generate(%q): %v", tt.layout, err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(tt.want))); err != nil {
+			t.Errorf("layout %q: %v", tt.layout, err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/X-for/ltgo/internal/models"
+)
+
+// DefaultLayout 默认文件名，如 "1_two-sum.go"
+const DefaultLayout = "{id}_{slug}.{ext}"
+
+// FileName 按 layout 生成题解文件的相对路径
+// 占位符: {id} 前端 ID，{id4} 补零到 4 位的 ID，{slug}，{difficulty} (小写)，{lang}，{ext}
+// 例: "{slug}/main.{ext}" 每题一个目录，"{difficulty}/{id4}-{slug}.{ext}" 按难度分目录
+func FileName(layout string, q *models.QuestionDetail, langConf LangConfig) (string, error) {
+	if layout == "" {
+		layout = DefaultLayout
+	}
+
+	id4 := q.QuestionFrontendID
+	if len(id4) < 4 && isDigits(id4) {
+		id4 = strings.Repeat("0", 4-len(id4)) + id4
+	}
+	name := strings.NewReplacer(
+		"{id}", q.QuestionFrontendID,
+		"{id4}", id4,
+		"{slug}", q.TitleSlug,
+		"{difficulty}", strings.ToLower(q.Difficulty),
+		"{lang}", langConf.Slug,
+		"{ext}", langConf.Extension,
+	).Replace(layout)
+
+	name = filepath.Clean(filepath.FromSlash(name))
+	if strings.Contains(name, "{") || filepath.IsAbs(name) || name == "." || strings.HasPrefix(name, "..") {
+		return "", fmt.Errorf("invalid layout '%s'", layout)
+	}
+	return name, nil
+}
+
+// ownDir layout 的目录部分带 {id} / {id4} / {slug}，每题一个目录
+func ownDir(layout string) bool {
+	if layout == "" {
+		layout = DefaultLayout
+	}
+	i := strings.LastIndex(layout, "/")
+	if i < 0 {
+		return false
+	}
+	dir := layout[:i]
+	return strings.Contains(dir, "{id}") || strings.Contains(dir, "{id4}") || strings.Contains(dir, "{slug}")
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// templateData 题解模板 (text/template) 里能用的字段
+// Meta 和 Code 必须出现在模板里，run/submit 靠它们找到题目和代码
+type templateData struct {
+	ID          string
+	Title       string
+	Slug        string
+	Difficulty  string
+	Lang        string
+	Site        string // leetcode.cn / leetcode.com
+	Meta        string // @lc 元数据注释块
+	Description string // 题目描述注释块
+	Code        string // 带 @lc code=start/end 标记的代码模板
+}
+
+func renderTemplate(path string, data templateData) (string, error) {
+	tmpl, err := template.ParseFiles(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to load template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", path, err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "@lc slug="+data.Slug) || !strings.Contains(out, "@lc code=start") {
+		return "", fmt.Errorf("template %s must include {{.Meta}} and {{.Code}}", path)
+	}
+	return out, nil
+}
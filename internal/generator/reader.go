@@ -78,3 +78,17 @@ func ParseLangFromMeta(filePath string) (string, error) {
 	}
 	return "", fmt.Errorf("lang metadata not found")
 }
+
+// ParseIDFromMeta 从 @lc app=... id=xxx 中提取前端 ID
+func ParseIDFromMeta(filePath string) (string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	re := regexp.MustCompile(`@lc\s+.*\bid=([^\s]+)`)
+	matches := re.FindStringSubmatch(string(content))
+	if len(matches) > 1 {
+		return matches[1], nil
+	}
+	return "", fmt.Errorf("id metadata not found")
+}
@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/X-for/ltgo/internal/models"
+	"github.com/X-for/ltgo/internal/render"
+)
+
+// ReadmePath Markdown 题面的位置
+// 每题一个目录的 layout (如 "{slug}/main.{ext}") 写成目录里的 README.md，否则写在题解旁边: 1_two-sum.md
+func ReadmePath(solutionPath, layout string) string {
+	if dir := filepath.Dir(filepath.FromSlash(layout)); dir != "." && strings.Contains(dir, "{") {
+		return filepath.Join(filepath.Dir(solutionPath), "README.md")
+	}
+	return strings.TrimSuffix(solutionPath, filepath.Ext(solutionPath)) + ".md"
+}
+
+// WriteReadme 把题目描述 (descLang 选的语言，见 render.Description) 转成 Markdown 写到 mdPath
+// 图片下载到同目录的 assets/ 下并改成相对链接，离线也能看；download 为 nil 或下载失败时保留原链接
+func WriteReadme(q *models.QuestionDetail, mdPath, descLang string, download func(url string) ([]byte, error)) error {
+	if _, err := os.Stat(mdPath); err == nil {
+		return fmt.Errorf("file already exists: %s", mdPath)
+	}
+
+	assets := filepath.Join(filepath.Dir(mdPath), "assets")
+	saved := map[string]string{} // 原地址 -> 新链接，同一张图只下载一次
+	image := func(src string) string {
+		if link, ok := saved[src]; ok {
+			return link
+		}
+		link := src
+		if download != nil && src != "" {
+			if name, err := saveImage(assets, fmt.Sprintf("%s-%d", q.TitleSlug, len(saved)+1), src, download); err != nil {
+				fmt.Printf("Skipping image %s: %v\n", src, err)
+			} else {
+				link = "assets/" + name
+			}
+		}
+		saved[src] = link
+		return link
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s. %s\n\n", q.QuestionFrontendID, render.Title(q, descLang))
+	fmt.Fprintf(&sb, "**Difficulty:** %s\n\n", q.Difficulty)
+	sb.WriteString(render.Markdown(render.Description(q, descLang), image))
+	sb.WriteString("\n")
+
+	fmt.Printf("Generating file: %s\n", mdPath)
+	return os.WriteFile(mdPath, []byte(sb.String()), 0644)
+}
+
+// saveImage 下载图片存成 dir/name.ext，返回文件名
+// 后缀优先用地址里的，没有时按内容判断
+func saveImage(dir, name, src string, download func(url string) ([]byte, error)) (string, error) {
+	data, err := download(src)
+	if err != nil {
+		return "", err
+	}
+
+	ext := ""
+	if u, err := url.Parse(src); err == nil {
+		ext = strings.ToLower(path.Ext(u.Path))
+	}
+	if ext == "" || len(ext) > 5 {
+		switch ct := http.DetectContentType(data); {
+		case strings.HasPrefix(ct, "image/jpeg"):
+			ext = ".jpg"
+		case strings.HasPrefix(ct, "image/gif"):
+			ext = ".gif"
+		case strings.HasPrefix(ct, "image/webp"):
+			ext = ".webp"
+		case strings.Contains(ct, "xml") || strings.HasPrefix(ct, "text/plain"):
+			ext = ".svg"
+		default:
+			ext = ".png"
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	file := name + ext
+	if err := os.WriteFile(filepath.Join(dir, file), data, 0644); err != nil {
+		return "", err
+	}
+	return file, nil
+}
@@ -0,0 +1,556 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/X-for/ltgo/internal/codec"
+	"github.com/X-for/ltgo/internal/models"
+	"github.com/X-for/ltgo/internal/problem"
+)
+
+// errNoExamples 描述里解析不出可用的示例，不生成测试文件
+var errNoExamples = errors.New("no usable examples found")
+
+// HelpersFile 测试用的辅助函数和 ListNode / TreeNode，同一目录下的测试文件共用一份
+const HelpersFile = "ltgo_helpers_test.go"
+
+// GenerateTest 根据题目示例生成表驱动的 _test.go (只支持 Go)
+// path 是测试文件的完整路径，已存在时不覆盖；同目录下没有 HelpersFile 时一起生成
+func GenerateTest(q *models.QuestionDetail, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("file already exists: %s", path)
+	}
+
+	// 题解文件已经写好了，看看里面有没有自己声明 ListNode / TreeNode
+	solution, _ := os.ReadFile(strings.TrimSuffix(path, "_test.go") + ".go")
+	src, err := testSource(q, declaredTypes(string(solution)))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Generating test file: %s\n", path)
+	if err := os.WriteFile(path, src, 0644); err != nil {
+		return err
+	}
+	return writeHelpers(filepath.Dir(path))
+}
+
+// writeHelpers 目录下还没有 HelpersFile 时写一份
+// 每个测试文件各带一份的话，同一目录下 go test 会报重复声明
+func writeHelpers(dir string) error {
+	path := filepath.Join(dir, HelpersFile)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	src, err := format.Source([]byte(helpersSource))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Generating test helpers: %s\n", path)
+	return os.WriteFile(path, src, 0644)
+}
+
+// goSnippet 题目的 Go 代码模板，没有时为空
+func goSnippet(q *models.QuestionDetail) string {
+	for _, s := range q.CodeSnippets {
+		if s.LangSlug == "golang" {
+			return s.Code
+		}
+	}
+	return ""
+}
+
+// isDesign 设计类题目 (有 Constructor 的)
+func isDesign(q *models.QuestionDetail) bool {
+	meta, err := problem.ParseMeta(q.MetaData)
+	if err != nil {
+		meta = nil
+	}
+	return problem.IsDesign(meta, goSnippet(q))
+}
+
+// declaredTypes 题解代码里声明了的类型名
+func declaredTypes(code string) map[string]bool {
+	declared := map[string]bool{}
+	file, err := parser.ParseFile(token.NewFileSet(), "solution.go", code, 0)
+	if err != nil {
+		return declared
+	}
+	for name, obj := range file.Scope.Objects {
+		if obj.Kind == ast.Typ {
+			declared[name] = true
+		}
+	}
+	return declared
+}
+
+// testSource 生成测试文件内容，declared 里的类型 (题解自己声明的) 不再声明
+func testSource(q *models.QuestionDetail, declared map[string]bool) ([]byte, error) {
+	snippet := goSnippet(q)
+	if snippet == "" {
+		return nil, fmt.Errorf("no Go code snippet")
+	}
+
+	name := ""
+	meta, err := problem.ParseMeta(q.MetaData)
+	if err == nil {
+		if meta.Manual {
+			return nil, fmt.Errorf("test generation is not supported for this problem type")
+		}
+		name = meta.Name
+	} else {
+		meta = nil
+	}
+
+	g := &testFile{imports: map[string]bool{"testing": true}, types: map[string]bool{}}
+	var body string
+	if problem.IsDesign(meta, snippet) {
+		body, err = g.classTest(q, snippet)
+	} else {
+		body, err = g.funcTest(q, snippet, meta, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("package main\n\n")
+	sb.WriteString("// 由 ltgo 根据题目示例生成，可以继续追加自己的用例\n")
+	sb.WriteString("// 运行: ltgo test 题解文件 (会自动补上题解用到的 import)\n")
+	sb.WriteString("// 或者: go test 题解文件 本文件 " + HelpersFile + " (题解里要自己写好 import)\n")
+	sb.WriteString("// 辅助函数 ltgoEqual、ltgoNewList、ltgoNewTree 等在 " + HelpersFile + " 里\n\n")
+	sb.WriteString(g.importBlock())
+	sb.WriteString(body)
+	if g.types["ListNode"] && !declared["ListNode"] {
+		sb.WriteString(listNodeSource)
+	}
+	if g.types["TreeNode"] && !declared["TreeNode"] {
+		sb.WriteString(treeNodeSource)
+	}
+
+	return format.Source([]byte(sb.String()))
+}
+
+// funcTest 普通题: 一个表驱动的 TestXxx
+func (g *testFile) funcTest(q *models.QuestionDetail, snippet string, meta *problem.Meta, name string) (string, error) {
+
+	fn, err := problem.ParseGoFunc(snippet, name)
+	if err != nil {
+		return "", err
+	}
+
+	outputParam := 0
+	if meta != nil && meta.Output.ParamIndex != nil {
+		outputParam = *meta.Output.ParamIndex
+	}
+	wantType := fn.Result
+	if wantType == "" {
+		if outputParam >= len(fn.Params) {
+			return "", errNoExamples
+		}
+		wantType = fn.Params[outputParam].Type
+	}
+
+	// 示例转成 Go 字面量，转不了的 (比如类型不支持) 直接跳过
+	var rows []string
+	for _, c := range problem.Cases(q, fn.ParamNames()) {
+		if c.Expected == "" {
+			continue
+		}
+		row, ok := testRow(fn, c, wantType)
+		if ok {
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 {
+		return "", errNoExamples
+	}
+	return g.testFunc(fn, rows, wantType, outputParam), nil
+}
+
+// classTest 设计类题目: 每个示例一个子测试，按顺序重放操作并逐个比较返回值
+func (g *testFile) classTest(q *models.QuestionDetail, snippet string) (string, error) {
+	class, err := problem.ParseGoClass(snippet)
+	if err != nil {
+		return "", err
+	}
+	byOp := map[string]*problem.Func{class.Name: class.Constructor}
+	for _, name := range class.MethodNames() {
+		byOp[problem.OpName(name)] = class.Methods[name]
+	}
+
+	var cases []string
+	for _, c := range problem.Cases(q, []string{"operations", "arguments"}) {
+		if c.Expected == "" {
+			continue
+		}
+		if steps, ok := g.classSteps(byOp, c); ok {
+			cases = append(cases, steps)
+		}
+	}
+	if len(cases) == 0 {
+		return "", errNoExamples
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "func Test%s(t *testing.T) {\n", class.Name)
+	for i, steps := range cases {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "\tt.Run(\"case %d\", func(t *testing.T) {\n", i+1)
+		sb.WriteString(steps)
+		sb.WriteString("\t})\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String(), nil
+}
+
+// classSteps 把一个示例 (操作名 / 参数 / 期望输出三个数组) 展开成调用语句
+func (g *testFile) classSteps(byOp map[string]*problem.Func, c problem.Case) (string, bool) {
+	var ops []string
+	if err := codec.Decode(c.Args[0], &ops); err != nil || len(ops) == 0 {
+		return "", false
+	}
+	args, err := codec.Split(c.Args[1])
+	if err != nil || len(args) != len(ops) {
+		return "", false
+	}
+	wants, err := codec.Split(c.Expected)
+	if err != nil || len(wants) != len(ops) {
+		return "", false
+	}
+
+	var sb strings.Builder
+	for i, op := range ops {
+		fn := byOp[op]
+		if fn == nil || (i == 0) != (fn.Name == "Constructor") {
+			return "", false
+		}
+		params, err := codec.Split(args[i])
+		if err != nil || len(params) != len(fn.Params) {
+			return "", false
+		}
+		lits := make([]string, len(params))
+		for j, p := range fn.Params {
+			g.noteType(p.Type)
+			if lits[j], err = codec.Literal(params[j], p.Type); err != nil {
+				return "", false
+			}
+		}
+
+		if i == 0 {
+			fmt.Fprintf(&sb, "\t\tobj := Constructor(%s)\n", strings.Join(lits, ", "))
+			continue
+		}
+		call := fmt.Sprintf("obj.%s(%s)", exportName(op), strings.Join(lits, ", "))
+		if fn.Result == "" || wants[i] == "null" {
+			fmt.Fprintf(&sb, "\t\t%s\n", call)
+			continue
+		}
+		g.noteType(fn.Result)
+		want, err := codec.Literal(wants[i], fn.Result)
+		if err != nil {
+			return "", false
+		}
+		if typedConst(fn.Result) {
+			// 短变量声明里的常量默认是 int / float64 / rune，要显式转换成返回值类型
+			want = fn.Result + "(" + want + ")"
+		}
+		fmt.Fprintf(&sb, "\t\tif got, want := %s, %s; %s {\n", call, want, g.mismatch(fn.Result, "want"))
+		fmt.Fprintf(&sb, "\t\t\tt.Errorf(\"op %d %s: got %%v, want %%v\", %s, %s)\n", i, op, show(fn.Result, "got"), show(fn.Result, "want"))
+		sb.WriteString("\t\t}\n")
+	}
+	return sb.String(), true
+}
+
+// testRow 一行用例: {参数..., 期望}
+func testRow(fn *problem.Func, c problem.Case, wantType string) (string, bool) {
+	var vals []string
+	for i, p := range fn.Params {
+		lit, err := codec.Literal(c.Args[i], p.Type)
+		if err != nil {
+			return "", false
+		}
+		vals = append(vals, lit)
+	}
+	want, err := codec.Literal(c.Expected, wantType)
+	if err != nil {
+		return "", false
+	}
+	vals = append(vals, want)
+	return "{" + strings.Join(vals, ", ") + "}", true
+}
+
+// testFile 记录生成过程中用到的 import 和链表、树类型
+type testFile struct {
+	imports map[string]bool
+	types   map[string]bool // "ListNode", "TreeNode"
+}
+
+func (g *testFile) noteType(typ string) {
+	for _, name := range []string{"ListNode", "TreeNode"} {
+		if strings.Contains(typ, name) {
+			g.types[name] = true
+		}
+	}
+}
+
+func (g *testFile) testFunc(fn *problem.Func, rows []string, wantType string, outputParam int) string {
+	var sb strings.Builder
+
+	fields := make([]string, len(fn.Params))
+	for i, p := range fn.Params {
+		fields[i] = p.Name
+		if p.Name == "want" {
+			fields[i] = "want_"
+		}
+		g.noteType(p.Type)
+	}
+	g.noteType(wantType)
+
+	fmt.Fprintf(&sb, "func Test%s(t *testing.T) {\n", exportName(fn.Name))
+	sb.WriteString("\ttests := []struct {\n")
+	for i, p := range fn.Params {
+		fmt.Fprintf(&sb, "\t\t%s %s\n", fields[i], p.Type)
+	}
+	fmt.Fprintf(&sb, "\t\twant %s\n", wantType)
+	sb.WriteString("\t}{\n")
+	for _, row := range rows {
+		fmt.Fprintf(&sb, "\t\t%s,\n", row)
+	}
+	sb.WriteString("\t}\n\n")
+
+	args := make([]string, len(fields))
+	for i, f := range fields {
+		args[i] = "tt." + f
+	}
+	call := fmt.Sprintf("%s(%s)", fn.Name, strings.Join(args, ", "))
+
+	sb.WriteString("\tfor i, tt := range tests {\n")
+	if fn.Result != "" {
+		fmt.Fprintf(&sb, "\t\tgot := %s\n", call)
+	} else {
+		// 原地修改类的题，比较的是被修改的参数
+		fmt.Fprintf(&sb, "\t\t%s\n", call)
+		fmt.Fprintf(&sb, "\t\tgot := tt.%s\n", fields[outputParam])
+	}
+
+	fmt.Fprintf(&sb, "\t\tif %s {\n", g.mismatch(wantType, "tt.want"))
+	fmt.Fprintf(&sb, "\t\t\tt.Errorf(\"case %%d: got %%v, want %%v\", i+1, %s, %s)\n", show(wantType, "got"), show(wantType, "tt.want"))
+	sb.WriteString("\t\t}\n")
+	sb.WriteString("\t}\n")
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// mismatch got 和 want 不相等的判断条件
+func (g *testFile) mismatch(typ, want string) string {
+	switch typ {
+	case "float64", "float32":
+		g.imports["math"] = true
+		return fmt.Sprintf("math.Abs(float64(got-%s)) > 1e-5", want)
+	case "int", "int64", "bool", "string", "byte", "rune":
+		return "got != " + want
+	default:
+		return fmt.Sprintf("!ltgoEqual(got, %s)", want)
+	}
+}
+
+// show 出错时打印 expr 的写法，链表和树用 ltgoFormat 打印成 LeetCode 的格式
+func show(typ, expr string) string {
+	if strings.Contains(typ, "ListNode") || strings.Contains(typ, "TreeNode") {
+		return "ltgoFormat(" + expr + ")"
+	}
+	return expr
+}
+
+func (g *testFile) importBlock() string {
+	var paths []string
+	for p := range g.imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var sb strings.Builder
+	sb.WriteString("import (\n")
+	for _, p := range paths {
+		fmt.Fprintf(&sb, "\t%q\n", p)
+	}
+	sb.WriteString(")\n\n")
+	return sb.String()
+}
+
+// typedConst 字面量默认类型和 typ 不一致的基本类型
+func typedConst(typ string) bool {
+	switch typ {
+	case "int", "bool", "string", "rune":
+		return false
+	}
+	return !strings.ContainsAny(typ, "[]*")
+}
+
+func exportName(name string) string {
+	if name == "" {
+		return name
+	}
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// helpersSource HelpersFile 的内容
+// 名字都带 ltgo 前缀，也不声明 ListNode / TreeNode (用类型参数)，免得和题解里的名字撞上
+const helpersSource = `package main
+
+// 由 ltgo 生成，同一目录下的测试文件共用
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ltgoEqual 同 reflect.DeepEqual，但 nil 切片和空切片算相等
+func ltgoEqual(got, want any) bool {
+	g, w := reflect.ValueOf(got), reflect.ValueOf(want)
+	if g.Kind() == reflect.Slice && w.Kind() == reflect.Slice && g.Len() == 0 && w.Len() == 0 {
+		return true
+	}
+	return reflect.DeepEqual(got, want)
+}
+
+// ltgoParseValues 解析 "[1,null,2]"，null 对应 nil
+func ltgoParseValues(s string) []*int {
+	s = strings.Trim(strings.TrimSpace(s), "[]")
+	if s == "" {
+		return nil
+	}
+	var vals []*int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "null" {
+			vals = append(vals, nil)
+			continue
+		}
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			panic("invalid value: " + part)
+		}
+		vals = append(vals, &v)
+	}
+	return vals
+}
+
+// ltgoNode 新建一个 Val 为 v 的节点 (ListNode / TreeNode)
+func ltgoNode[T any](v int) *T {
+	node := new(T)
+	reflect.ValueOf(node).Elem().FieldByName("Val").SetInt(int64(v))
+	return node
+}
+
+// ltgoChild 节点的 Next / Left / Right 字段
+func ltgoChild[T any](node *T, field string) **T {
+	return reflect.ValueOf(node).Elem().FieldByName(field).Addr().Interface().(**T)
+}
+
+// ltgoNewList 由 "[1,2,3]" 建链表，如 ltgoNewList[ListNode]("[1,2,3]")
+func ltgoNewList[T any](s string) *T {
+	var head *T
+	next := &head
+	for _, v := range ltgoParseValues(s) {
+		*next = ltgoNode[T](*v)
+		next = ltgoChild(*next, "Next")
+	}
+	return head
+}
+
+// ltgoNewTree 按层序 (null 表示空节点) 建树，如 ltgoNewTree[TreeNode]("[1,null,2,3]")
+func ltgoNewTree[T any](s string) *T {
+	vals := ltgoParseValues(s)
+	if len(vals) == 0 || vals[0] == nil {
+		return nil
+	}
+	root := ltgoNode[T](*vals[0])
+	queue := []*T{root}
+	for i := 1; i < len(vals); i += 2 {
+		node := queue[0]
+		queue = queue[1:]
+		for j, field := range []string{"Left", "Right"} {
+			if i+j < len(vals) && vals[i+j] != nil {
+				child := ltgoNode[T](*vals[i+j])
+				*ltgoChild(node, field) = child
+				queue = append(queue, child)
+			}
+		}
+	}
+	return root
+}
+
+// ltgoFormat 出错时打印的值: 链表和树按 LeetCode 的写法 ([1,2,3] / [1,null,2])，其他同 %v
+func ltgoFormat(v any) string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice {
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			parts[i] = ltgoFormat(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	}
+	if rv.Kind() != reflect.Pointer || rv.Type().Elem().Kind() != reflect.Struct {
+		return fmt.Sprint(v)
+	}
+
+	var parts []string
+	if _, ok := rv.Type().Elem().FieldByName("Next"); ok {
+		for ; !rv.IsNil(); rv = rv.Elem().FieldByName("Next") {
+			parts = append(parts, strconv.FormatInt(rv.Elem().FieldByName("Val").Int(), 10))
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	}
+	queue := []reflect.Value{rv}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node.IsNil() {
+			parts = append(parts, "null")
+			continue
+		}
+		parts = append(parts, strconv.FormatInt(node.Elem().FieldByName("Val").Int(), 10))
+		queue = append(queue, node.Elem().FieldByName("Left"), node.Elem().FieldByName("Right"))
+	}
+	for len(parts) > 0 && parts[len(parts)-1] == "null" {
+		parts = parts[:len(parts)-1]
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+`
+
+// listNodeSource / treeNodeSource 题解里的定义是注释掉的，测试文件里补上
+const listNodeSource = `
+// ListNode 题解里的定义是注释掉的，这里补上；题解自己声明了的话删掉这个
+type ListNode struct {
+	Val  int
+	Next *ListNode
+}
+`
+
+const treeNodeSource = `
+// TreeNode 题解里的定义是注释掉的，这里补上；题解自己声明了的话删掉这个
+type TreeNode struct {
+	Val   int
+	Left  *TreeNode
+	Right *TreeNode
+}
+`
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/X-for/ltgo/internal/models"
+)
+
+func listQuestion(name string) *models.QuestionDetail {
+	return &models.QuestionDetail{
+		Content:          "<pre><strong>Input:</strong> head = [1,2,3]\n<strong>Output:</strong> [3,2,1]</pre>",
+		ExampleTestcases: "[1,2,3]",
+		MetaData:         `{"name":"` + name + `","params":[{"name":"head","type":"ListNode"}],"return":{"type":"ListNode"}}`,
+		CodeSnippets:     []models.CodeSnippet{{LangSlug: "golang", Code: "func " + name + "(head *ListNode) *ListNode {\n\n}"}},
+	}
+}
+
+const reverseList = `
+func reverseList(head *ListNode) *ListNode {
+	var prev *ListNode
+	for head != nil {
+		head.Next, prev, head = prev, head, head.Next
+	}
+	return prev
+}
+`
+
+// goCheck 在 dir 里对题解、生成的测试和辅助文件跑 go vet 和 go test
+func goCheck(t *testing.T, dir string, files ...string) {
+	t.Helper()
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go not found")
+	}
+	for _, args := range [][]string{{"vet"}, {"test"}} {
+		cmd := exec.Command(goBin, append(args, files...)...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOTOOLCHAIN=local", "GOFLAGS=-mod=mod")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("go %s: %v\n%s", args[0], err, out)
+		}
+	}
+}
+
+// 生成的测试要能和题解一起编译，不管题解有没有自己声明 ListNode 和同名的辅助函数
+func TestGenerateTestCompiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		solution string
+	}{
+		{"commented", "/**\n * type ListNode struct {\n *     Val int\n *     Next *ListNode\n * }\n */\n" + reverseList},
+		{"declared", "type ListNode struct {\n\tVal  int\n\tNext *ListNode\n}\n\nfunc equal(a, b int) bool { return a == b }\n\nfunc newList() {}\n" + reverseList},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module sol\n\ngo 1.21\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "206_reverse.go"), []byte("package main\n"+tt.solution), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := GenerateTest(listQuestion("reverseList"), filepath.Join(dir, "206_reverse_test.go")); err != nil {
+				t.Fatal(err)
+			}
+			goCheck(t, dir, "206_reverse.go", "206_reverse_test.go", HelpersFile)
+		})
+	}
+}
+
+// 同一目录下的多个测试文件共用一份辅助函数，不能各自声明
+func TestGenerateTestSharedHelpers(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"reverseList", "sortList"} {
+		path := filepath.Join(dir, name+"_test.go")
+		if err := GenerateTest(listQuestion(name), path); err != nil {
+			t.Fatalf("GenerateTest(%s): %v", name, err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range []string{"func ltgoNewList", "func ltgoEqual"} {
+			if strings.Contains(string(data), decl) {
+				t.Errorf("%s declares %q", name, decl)
+			}
+		}
+	}
+
+	helpers := filepath.Join(dir, HelpersFile)
+	data, err := os.ReadFile(helpers)
+	if err != nil {
+		t.Fatalf("helpers file: %v", err)
+	}
+	for _, decl := range []string{"func ltgoNewList", "func ltgoNewTree", "func ltgoEqual", "func ltgoFormat"} {
+		if !strings.Contains(string(data), decl) {
+			t.Errorf("helpers file is missing %q", decl)
+		}
+	}
+	if strings.Contains(string(data), "type ListNode") {
+		t.Error("helpers file should not declare ListNode")
+	}
+
+	// 已有的辅助文件 (可能被改过) 不覆盖
+	if err := os.WriteFile(helpers, []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := GenerateTest(listQuestion("middleNode"), filepath.Join(dir, "middleNode_test.go")); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(helpers); string(data) != "package main\n" {
+		t.Error("existing helpers file was overwritten")
+	}
+}
@@ -4,11 +4,19 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/X-for/ltgo/internal/render"
 	"github.com/jaytaylor/html2text"
 )
 
-// htmlToText 将 HTML 转换为适合放在 Go 注释里的纯文本
-func htmlToText(html string) string {
+// DefaultWrap 题目描述注释的默认折行宽度
+const DefaultWrap = 80
+
+// htmlToText 将 HTML 转换为适合放在 Go 注释里的纯文本，并按 width 折行 (<= 0 不折行)
+func htmlToText(html string, width int) string {
+	return render.Wrap(convertHTML(render.Normalize(html)), width)
+}
+
+func convertHTML(html string) string {
 	// 使用 jaytaylor/html2text 库进行转换
 	// 这个库能很好地处理表格、列表、链接等复杂结构
 	text, err := html2text.FromString(html, html2text.Options{
@@ -0,0 +1,212 @@
+package harness
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/X-for/ltgo/internal/codec"
+	"github.com/X-for/ltgo/internal/problem"
+)
+
+// DefaultTimeout 整个测试程序 (不含编译) 的运行时间上限
+const DefaultTimeout = 10 * time.Second
+
+// Spec 一次本地测试需要的全部信息
+type Spec struct {
+	Code        string         // @lc code 区域的代码
+	Func        *problem.Func  // 入口函数 (普通题)
+	Class       *problem.Class // 设计类题目，非 nil 时忽略 Func
+	OutputParam int            // 入口函数没有返回值时，输出的是第几个参数
+	Cases       []problem.Case
+	Timeout     time.Duration
+}
+
+// Result 单个用例的运行结果
+type Result struct {
+	Args     []string
+	Output   string
+	Expected string
+	Error    string // 解码失败、panic 等
+}
+
+// Checked 有期望输出可以比对
+func (r Result) Checked() bool {
+	return r.Expected != ""
+}
+
+// Passed 运行成功且和期望输出一致
+func (r Result) Passed() bool {
+	return r.Error == "" && (!r.Checked() || codec.Equal(r.Output, r.Expected))
+}
+
+// Report 一次运行的汇总
+type Report struct {
+	Results []Result
+	Stdout  string // 题解里的打印输出
+}
+
+// BuildError 题解编译失败
+type BuildError struct {
+	Output string
+}
+
+func (e *BuildError) Error() string {
+	return "compile error:\n" + e.Output
+}
+
+// Run 在临时目录里生成 Go 程序，编译并跑完所有用例
+// ctx 取消时编译和运行都会立刻停下
+func Run(ctx context.Context, spec Spec) (*Report, error) {
+	if len(spec.Cases) == 0 {
+		return nil, errors.New("no test cases")
+	}
+	if err := validate(spec); err != nil {
+		return nil, err
+	}
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		return nil, errors.New("local testing needs the Go toolchain ('go' not found in PATH)")
+	}
+
+	dir, err := os.MkdirTemp("", "ltgo-test-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"go.mod":       "module ltgotest\n\ngo 1.21\n",
+		"solution.go":  solutionSource(spec.Code),
+		"codec.go":     codec.Source("main", declaredTypes(spec.Code)...),
+		"ltgo_main.go": mainSource(spec),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	// 1. 编译
+	bin := filepath.Join(dir, "solution")
+	build := exec.CommandContext(ctx, goBin, "build", "-o", bin, ".")
+	build.Dir = dir
+	build.Env = append(os.Environ(), "GOTOOLCHAIN=local", "GOFLAGS=-mod=mod")
+	if out, err := build.CombinedOutput(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, &BuildError{Output: strings.TrimSpace(strings.ReplaceAll(string(out), dir+string(filepath.Separator), ""))}
+	}
+
+	// 2. 运行
+	timeout := spec.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resultFile := filepath.Join(dir, "results.json")
+	var stdout, stderr bytes.Buffer
+	run := exec.CommandContext(runCtx, bin, resultFile)
+	run.Dir = dir
+	run.Stdout = &stdout
+	run.Stderr = &stderr
+	if err := run.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if runCtx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("time limit exceeded (%s)", timeout)
+		}
+		return nil, fmt.Errorf("solution crashed: %v\n%s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	// 3. 读结果
+	data, err := os.ReadFile(resultFile)
+	if err != nil {
+		return nil, err
+	}
+	var raw []struct {
+		Output string `json:"output"`
+		Error  string `json:"error"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	report := &Report{Stdout: stdout.String()}
+	for i, c := range spec.Cases {
+		r := Result{Args: c.Args, Expected: c.Expected}
+		if i < len(raw) {
+			r.Output = raw[i].Output
+			r.Error = raw[i].Error
+		}
+		report.Results = append(report.Results, r)
+	}
+	return report, nil
+}
+
+// validate 检查入口和用例能不能生成驱动程序
+func validate(spec Spec) error {
+	var params []problem.Param
+	name := ""
+	switch {
+	case spec.Class != nil:
+		// 设计类: 每个用例固定两行 (操作名列表、参数列表)
+		params = []problem.Param{{Name: "operations"}, {Name: "arguments"}}
+		name = spec.Class.Name
+		fns := []*problem.Func{spec.Class.Constructor}
+		for _, m := range spec.Class.MethodNames() {
+			fns = append(fns, spec.Class.Methods[m])
+		}
+		for _, fn := range fns {
+			for _, p := range fn.Params {
+				if !supported(p.Type) {
+					return fmt.Errorf("parameter type %s of %s is not supported by local testing yet", p.Type, fn.Name)
+				}
+			}
+		}
+	case spec.Func != nil:
+		params = spec.Func.Params
+		name = spec.Func.Name
+		if spec.Func.Result == "" && spec.OutputParam >= len(params) {
+			return fmt.Errorf("function '%s' returns nothing and has no output parameter", name)
+		}
+		for _, p := range params {
+			if !supported(p.Type) {
+				return fmt.Errorf("parameter type %s is not supported by local testing yet", p.Type)
+			}
+		}
+	default:
+		return errors.New("no entry function")
+	}
+
+	for _, c := range spec.Cases {
+		if len(c.Args) != len(params) {
+			return fmt.Errorf("test case %v has %d values, but %s takes %d parameters", c.Args, len(c.Args), name, len(params))
+		}
+		for i, arg := range c.Args {
+			if err := codec.Validate(arg); err != nil {
+				return fmt.Errorf("invalid value for %s (%s): %w", params[i].Name, arg, err)
+			}
+		}
+	}
+	return nil
+}
+
+// supported codec 能处理的类型: 基本类型、切片、ListNode、TreeNode
+// N 叉树 (Node)、带随机指针的链表这类题暂不支持
+func supported(typ string) bool {
+	t := strings.NewReplacer("ListNode", "", "TreeNode", "").Replace(typ)
+	return !strings.Contains(t, "Node") && !strings.Contains(t, "func") && !strings.Contains(t, "map[")
+}
@@ -0,0 +1,91 @@
+package harness
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+)
+
+// stdPackages LeetCode 的 Go 环境会自动导入这些包，题解里可以直接用
+var stdPackages = map[string]string{
+	"bits":    "math/bits",
+	"bufio":   "bufio",
+	"bytes":   "bytes",
+	"cmp":     "cmp",
+	"errors":  "errors",
+	"fmt":     "fmt",
+	"heap":    "container/heap",
+	"list":    "container/list",
+	"maps":    "maps",
+	"math":    "math",
+	"rand":    "math/rand",
+	"regexp":  "regexp",
+	"ring":    "container/ring",
+	"slices":  "slices",
+	"sort":    "sort",
+	"strconv": "strconv",
+	"strings": "strings",
+	"time":    "time",
+	"unicode": "unicode",
+	"utf8":    "unicode/utf8",
+}
+
+// inferImports 找出代码里用到但没有 import 的标准库包
+// 只看 pkg.Name 形式的引用，而且 pkg 不能是代码里定义过的变量
+func inferImports(code string) []string {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "solution.go", "package main\n"+code, 0)
+	if err != nil {
+		// 语法错误交给编译器报
+		return nil
+	}
+
+	// 代码里自己写了的 import 不再重复加
+	have := map[string]bool{}
+	for _, spec := range file.Imports {
+		if path, err := strconv.Unquote(spec.Path.Value); err == nil {
+			have[path] = true
+		}
+	}
+
+	used := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok || ident.Obj != nil {
+			return true
+		}
+		if path, ok := stdPackages[ident.Name]; ok && !have[path] && file.Scope.Lookup(ident.Name) == nil {
+			used[path] = true
+		}
+		return true
+	})
+
+	imports := make([]string, 0, len(used))
+	for path := range used {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// declaredTypes 题解自己声明的 ListNode / TreeNode (比如把 LeetCode 注释里的定义放开了)
+// codec.go 里就不能再声明一遍
+func declaredTypes(code string) []string {
+	file, err := parser.ParseFile(token.NewFileSet(), "solution.go", "package main\n"+code, 0)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, name := range []string{"ListNode", "TreeNode"} {
+		if obj := file.Scope.Lookup(name); obj != nil && obj.Kind == ast.Typ {
+			names = append(names, name)
+		}
+	}
+	return names
+}
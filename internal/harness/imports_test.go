@@ -0,0 +1,33 @@
+package harness
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInferImports(t *testing.T) {
+	tests := []struct {
+		code string
+		want []string
+	}{
+		{"func f(s string) []string { return strings.Fields(s) }", []string{"strings"}},
+		{"func f(a []int) { sort.Ints(a); _ = math.MaxInt }", []string{"math", "sort"}},
+		{"import \"strings\"\n\nfunc f(s string) []string { return strings.Fields(s) }", []string{}},
+		{"import (\n\t\"sort\"\n)\n\nfunc f(a []int) { sort.Ints(a); _ = strconv.Itoa(1) }", []string{"strconv"}},
+		{"func f(strings []string) int { return strings.Len() }", []string{}},
+		{"func f( {", nil},
+	}
+	for _, tt := range tests {
+		if got := inferImports(tt.code); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("inferImports(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestSolutionSourceNoDuplicateImport(t *testing.T) {
+	code := "import \"strings\"\n\nfunc f(s string) []string { return strings.Fields(s) }"
+	want := "package main\n\n" + code + "\n"
+	if got := solutionSource(code); got != want {
+		t.Errorf("solutionSource = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,173 @@
+package harness
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/X-for/ltgo/internal/problem"
+)
+
+// solutionSource 用户代码 + 推断出的 import
+func solutionSource(code string) string {
+	var sb strings.Builder
+	sb.WriteString("package main\n\n")
+	if imports := inferImports(code); len(imports) > 0 {
+		sb.WriteString("import (\n")
+		for _, path := range imports {
+			fmt.Fprintf(&sb, "\t%q\n", path)
+		}
+		sb.WriteString(")\n\n")
+	}
+	sb.WriteString(code)
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// mainSource 驱动程序: 逐个解码参数、调用题解、把结果写成 JSON
+// 参数的编解码用同目录下的 codec.go (codecDecode / codecEncode / codecSplit)
+// 其余标识符都带 ltgo 前缀，避免和题解里的名字冲突
+func mainSource(spec Spec) string {
+	var sb strings.Builder
+	sb.WriteString("package main\n\nimport (\n\t\"encoding/json\"\n\t\"fmt\"\n\t\"os\"\n")
+	if spec.Class != nil {
+		sb.WriteString("\t\"strings\"\n")
+	}
+	sb.WriteString(`)
+
+type ltgoResult struct {
+	Output string ` + "`json:\"output\"`" + `
+	Error  string ` + "`json:\"error,omitempty\"`" + `
+}
+
+`)
+
+	// 测试数据
+	sb.WriteString("var ltgoCases = [][]string{\n")
+	for _, c := range spec.Cases {
+		sb.WriteString("\t{")
+		for i, arg := range c.Args {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(&sb, "%q", arg)
+		}
+		sb.WriteString("},\n")
+	}
+	sb.WriteString("}\n\n")
+
+	sb.WriteString(`func ltgoCall(args []string) (out string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+`)
+	if spec.Class != nil {
+		writeClassCall(&sb, spec.Class)
+	} else {
+		writeFuncCall(&sb, spec.Func, spec.OutputParam)
+	}
+	sb.WriteString("}\n\n")
+
+	sb.WriteString(`func main() {
+	results := make([]ltgoResult, len(ltgoCases))
+	for i, args := range ltgoCases {
+		out, err := ltgoCall(args)
+		results[i].Output = out
+		if err != nil {
+			results[i].Error = err.Error()
+		}
+	}
+	data, _ := json.Marshal(results)
+	if err := os.WriteFile(os.Args[1], data, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`)
+	return sb.String()
+}
+
+// writeDecodes 把 src[0..n) 解码到 prefix0, prefix1 ... 并返回变量名
+func writeDecodes(sb *strings.Builder, indent, src, prefix string, params []problem.Param, what string) []string {
+	names := make([]string, len(params))
+	for i, p := range params {
+		names[i] = fmt.Sprintf("%s%d", prefix, i)
+		fmt.Fprintf(sb, "%svar %s %s\n", indent, names[i], p.Type)
+		fmt.Fprintf(sb, "%sif err := codecDecode(%s[%d], &%s); err != nil {\n", indent, src, i, names[i])
+		fmt.Fprintf(sb, "%s\treturn \"\", fmt.Errorf(\"%sinvalid %s: %%w\", err)\n", indent, what, p.Name)
+		fmt.Fprintf(sb, "%s}\n", indent)
+	}
+	return names
+}
+
+// writeFuncCall 普通题: 解码参数，调用入口函数
+func writeFuncCall(sb *strings.Builder, fn *problem.Func, outputParam int) {
+	argNames := writeDecodes(sb, "\t", "args", "a", fn.Params, "")
+	call := fmt.Sprintf("%s(%s)", fn.Name, strings.Join(argNames, ", "))
+	if fn.Result != "" {
+		fmt.Fprintf(sb, "\treturn codecEncode(%s), nil\n", call)
+		return
+	}
+	// 没有返回值: 原地修改的参数就是输出
+	fmt.Fprintf(sb, "\t%s\n", call)
+	fmt.Fprintf(sb, "\treturn codecEncode(a%d), nil\n", outputParam)
+}
+
+// writeClassCall 设计类题目: args[0] 是操作名列表，args[1] 是每个操作的参数列表
+// 依次重放所有操作，输出和 LeetCode 一样的数组 (构造函数和无返回值的方法为 null)
+func writeClassCall(sb *strings.Builder, class *problem.Class) {
+	sb.WriteString(`	var ops []string
+	if err := codecDecode(args[0], &ops); err != nil {
+		return "", fmt.Errorf("invalid operations: %w", err)
+	}
+	opArgs, err := codecSplit(args[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid arguments: %w", err)
+	}
+	if len(opArgs) != len(ops) {
+		return "", fmt.Errorf("%d operations but %d argument lists", len(ops), len(opArgs))
+	}
+
+	outs := make([]string, len(ops))
+`)
+	fmt.Fprintf(sb, "\tvar obj %s\n", class.Constructor.Result)
+	sb.WriteString(`	for i, op := range ops {
+		params, err := codecSplit(opArgs[i])
+		if err != nil {
+			return "", fmt.Errorf("%s: invalid arguments: %w", op, err)
+		}
+		switch op {
+`)
+
+	writeOp := func(op string, fn *problem.Func, target string) {
+		fmt.Fprintf(sb, "\t\tcase %q:\n", op)
+		fmt.Fprintf(sb, "\t\t\tif len(params) != %d {\n", len(fn.Params))
+		fmt.Fprintf(sb, "\t\t\t\treturn \"\", fmt.Errorf(\"%%s takes %d arguments, got %%d\", op, len(params))\n", len(fn.Params))
+		sb.WriteString("\t\t\t}\n")
+		names := writeDecodes(sb, "\t\t\t", "params", "p", fn.Params, op+": ")
+		call := fmt.Sprintf("%s(%s)", target, strings.Join(names, ", "))
+		switch {
+		case fn.Name == "Constructor":
+			fmt.Fprintf(sb, "\t\t\tobj = %s\n", call)
+			sb.WriteString("\t\t\touts[i] = \"null\"\n")
+		case fn.Result == "":
+			fmt.Fprintf(sb, "\t\t\t%s\n", call)
+			sb.WriteString("\t\t\touts[i] = \"null\"\n")
+		default:
+			fmt.Fprintf(sb, "\t\t\touts[i] = codecEncode(%s)\n", call)
+		}
+	}
+
+	writeOp(class.Name, class.Constructor, "Constructor")
+	for _, name := range class.MethodNames() {
+		writeOp(problem.OpName(name), class.Methods[name], "obj."+name)
+	}
+
+	sb.WriteString(`		default:
+			return "", fmt.Errorf("unknown operation %q", op)
+		}
+	}
+	return "[" + strings.Join(outs, ",") + "]", nil
+`)
+}
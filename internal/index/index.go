@@ -0,0 +1,212 @@
+package index
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/X-for/ltgo/internal/config"
+	"github.com/X-for/ltgo/internal/models"
+)
+
+// MaxAge 超过这个时间没同步的索引被认为是过期的
+const MaxAge = 24 * time.Hour
+
+// Entry 索引里的一道题
+type Entry struct {
+	FrontendID      string `json:"frontendId"`
+	QuestionID      string `json:"questionId"` // 后端 ID，提交代码时要用
+	Slug            string `json:"slug"`
+	Title           string `json:"title"`
+	TranslatedTitle string `json:"translatedTitle,omitempty"`
+	Difficulty      string `json:"difficulty"`
+	PaidOnly        bool   `json:"paidOnly"`
+	Status          string `json:"status,omitempty"`
+}
+
+// Question 转回 models.Question，方便和搜索结果混用
+func (e Entry) Question() models.Question {
+	return models.Question{
+		QuestionID:         e.QuestionID,
+		QuestionFrontendID: e.FrontendID,
+		Title:              e.Title,
+		TranslatedTitle:    e.TranslatedTitle,
+		TitleSlug:          e.Slug,
+		Difficulty:         e.Difficulty,
+		Status:             e.Status,
+		PaidOnly:           e.PaidOnly,
+	}
+}
+
+// FromQuestion 把接口返回的题目转成索引条目
+func FromQuestion(q models.Question) Entry {
+	return Entry{
+		FrontendID:      q.QuestionFrontendID,
+		QuestionID:      q.BackendID(),
+		Slug:            q.TitleSlug,
+		Title:           q.Title,
+		TranslatedTitle: q.TranslatedTitle,
+		Difficulty:      q.Difficulty,
+		PaidOnly:        q.PaidOnly || q.IsPaidOnly,
+		Status:          q.Status,
+	}
+}
+
+// Index 本地题目索引 (~/.ltgo/index-<site>.json)
+type Index struct {
+	Site      string    `json:"site"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Total     int       `json:"total"` // 上次同步时服务端报告的总数
+	Questions []Entry   `json:"questions"`
+
+	path   string
+	byID   map[string]int
+	bySlug map[string]int
+}
+
+// Path 返回某个站点索引文件的路径
+func Path(site string) (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("index-%s.json", site)), nil
+}
+
+// Load 读取索引，文件不存在时返回一个空索引
+func Load(site string) (*Index, error) {
+	path, err := Path(site)
+	if err != nil {
+		return nil, err
+	}
+
+	ix := &Index{Site: site, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		ix.rebuild()
+		return ix, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, ix); err != nil {
+		return nil, fmt.Errorf("corrupted index %s (run 'ltgo sync --full'): %w", path, err)
+	}
+	ix.rebuild()
+	return ix, nil
+}
+
+// Save 写回磁盘 (先写临时文件再 rename，避免写一半被打断)
+func (ix *Index) Save() error {
+	if err := os.MkdirAll(filepath.Dir(ix.path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(ix)
+	if err != nil {
+		return err
+	}
+
+	tmp := ix.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, ix.path)
+}
+
+// Len 索引里的题目数量
+func (ix *Index) Len() int {
+	return len(ix.Questions)
+}
+
+// Stale 索引是否为空或者太久没同步
+func (ix *Index) Stale() bool {
+	return len(ix.Questions) == 0 || time.Since(ix.UpdatedAt) > MaxAge
+}
+
+// ByID 按前端 ID 查找
+func (ix *Index) ByID(id string) (Entry, bool) {
+	i, ok := ix.byID[id]
+	if !ok {
+		return Entry{}, false
+	}
+	return ix.Questions[i], true
+}
+
+// BySlug 按 slug 查找
+func (ix *Index) BySlug(slug string) (Entry, bool) {
+	i, ok := ix.bySlug[slug]
+	if !ok {
+		return Entry{}, false
+	}
+	return ix.Questions[i], true
+}
+
+// Lookup 同时尝试 ID 和 slug
+func (ix *Index) Lookup(key string) (Entry, bool) {
+	if e, ok := ix.ByID(key); ok {
+		return e, true
+	}
+	return ix.BySlug(key)
+}
+
+// Reset 清空所有题目 (全量同步前调用)
+func (ix *Index) Reset() {
+	ix.Questions = nil
+	ix.rebuild()
+}
+
+// Merge 合并一批题目，已存在的 (按 slug) 会被覆盖，返回新增的数量
+func (ix *Index) Merge(entries []Entry) int {
+	added := 0
+	for _, e := range entries {
+		if e.Slug == "" {
+			continue
+		}
+		if i, ok := ix.bySlug[e.Slug]; ok {
+			ix.Questions[i] = e
+			continue
+		}
+		ix.Questions = append(ix.Questions, e)
+		ix.bySlug[e.Slug] = len(ix.Questions) - 1
+		added++
+	}
+	ix.rebuild()
+	return added
+}
+
+// rebuild 排序并重建查找表
+func (ix *Index) rebuild() {
+	sort.SliceStable(ix.Questions, func(i, j int) bool {
+		return lessID(ix.Questions[i].FrontendID, ix.Questions[j].FrontendID)
+	})
+
+	ix.byID = make(map[string]int, len(ix.Questions))
+	ix.bySlug = make(map[string]int, len(ix.Questions))
+	for i, e := range ix.Questions {
+		ix.byID[e.FrontendID] = i
+		ix.bySlug[e.Slug] = i
+	}
+}
+
+// lessID 数字 ID 按数值排序，"LCR 001" 这类非数字 ID 排在后面按字典序
+func lessID(a, b string) bool {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return na < nb
+	case errA == nil:
+		return true
+	case errB == nil:
+		return false
+	default:
+		return a < b
+	}
+}
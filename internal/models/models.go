@@ -1,16 +1,28 @@
 package models
 
+import "encoding/json"
+
 // Question 基础题目信息 (适配 V2)
 type Question struct {
-	QuestionID         string `json:"questionId"` // 后端 ID
-	QuestionFrontendID string `json:"questionFrontendId"`
-	Title              string `json:"title"`
-	TranslatedTitle    string `json:"translatedTitle"` // 新增: 中文标题
-	TitleSlug          string `json:"titleSlug"`
-	Difficulty         string `json:"difficulty"` // "EASY", "MEDIUM", "HARD"
-	Status             string `json:"status"`     // "TO_DO", "AC", "TRIED" (可能为null)
-	PaidOnly           bool   `json:"paidOnly"`   // 注意: JSON 里是 paidOnly
-	IsPaidOnly         bool   `json:"isPaidOnly"` // 兼容旧版
+	QuestionID         string      `json:"questionId"` // 后端 ID
+	ID                 json.Number `json:"id"`         // V2 里后端 ID 的字段名是 id
+	QuestionFrontendID string      `json:"questionFrontendId"`
+	Title              string      `json:"title"`
+	TranslatedTitle    string      `json:"translatedTitle"` // 新增: 中文标题
+	TitleSlug          string      `json:"titleSlug"`
+	Difficulty         string      `json:"difficulty"` // "EASY", "MEDIUM", "HARD"
+	Status             string      `json:"status"`     // "TO_DO", "AC", "TRIED" (可能为null)
+	PaidOnly           bool        `json:"paidOnly"`   // 注意: JSON 里是 paidOnly
+	IsPaidOnly         bool        `json:"isPaidOnly"` // 兼容旧版
+	AcRate             float64     `json:"acRate"`     // 通过率，V2 是 0~1 的小数，旧版是百分数
+}
+
+// BackendID 返回后端 ID，兼容 V1 (questionId) 和 V2 (id)
+func (q *Question) BackendID() string {
+	if q.QuestionID != "" {
+		return q.QuestionID
+	}
+	return q.ID.String()
 }
 
 // CodeSnippet 代码模板
@@ -25,12 +37,16 @@ type QuestionDetail struct {
 	QuestionID         string        `json:"questionId"`
 	QuestionFrontendID string        `json:"questionFrontendId"`
 	Title              string        `json:"title"`
+	TranslatedTitle    string        `json:"translatedTitle"` // 中文标题 (CN特有)
 	TitleSlug          string        `json:"titleSlug"`
 	Content            string        `json:"content"`           // 题目描述 (HTML)
 	TranslatedContent  string        `json:"translatedContent"` // 中文描述 (CN特有)
 	Difficulty         string        `json:"difficulty"`
+	IsPaidOnly         bool          `json:"isPaidOnly"`   // 会员题
 	CodeSnippets       []CodeSnippet `json:"codeSnippets"` // 各语言代码模板
 	SampleTestCase     string        `json:"sampleTestCase"`
+	ExampleTestcases   string        `json:"exampleTestcases"` // 所有示例的输入，按行拼接
+	MetaData           string        `json:"metaData"`         // JSON 字符串: 函数名、参数类型等
 }
 
 // QuestionListResponse 题目列表的响应结构
@@ -38,8 +54,10 @@ type QuestionListResponse struct {
 	Data struct {
 		// 兼容 V2
 		ProblemsetQuestionListV2 struct {
-			Total     int        `json:"total"` // 如果 V2 不返回这个，可能就是 0
-			Questions []Question `json:"questions"`
+			Total       int        `json:"total"`       // 如果 V2 不返回这个，可能就是 0
+			TotalLength int        `json:"totalLength"` // V2 实际返回的总数字段
+			HasMore     bool       `json:"hasMore"`
+			Questions   []Question `json:"questions"`
 		} `json:"problemsetQuestionListV2"`
 
 		// 保留旧版兼容 (可选)
@@ -0,0 +1,105 @@
+package problem
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Class 设计类题目 (Min Stack / LRU Cache) 的 Go 结构
+type Class struct {
+	Name        string           // 结构体名，也是操作序列里第一个操作的名字
+	Constructor *Func            // func Constructor(...) T
+	Methods     map[string]*Func // 按 Go 方法名 (Push / GetMin)
+}
+
+// IsDesign 判断是不是设计类题目: 优先看 metaData，没有时看代码里有没有 Constructor
+func IsDesign(meta *Meta, code string) bool {
+	if meta != nil && (meta.SystemDesign || meta.ClassName != "") {
+		return true
+	}
+	return strings.Contains(code, "func Constructor(")
+}
+
+// ParseGoClass 从 Go 代码里解析出设计类题目的构造函数和方法
+func ParseGoClass(code string) (*Class, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "solution.go", "package main\n"+code, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse solution: %w", err)
+	}
+
+	c := &Class{}
+	methods := map[string]map[string]*Func{} // 接收者类型 -> 方法，题解里可能还有 heap 之类的辅助类型
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		if fn.Recv == nil {
+			if fn.Name.Name == "Constructor" {
+				c.Constructor = funcFromDecl(fset, fn)
+				c.Name = strings.TrimPrefix(c.Constructor.Result, "*")
+			}
+			continue
+		}
+		recv := recvName(fn.Recv.List[0].Type)
+		if methods[recv] == nil {
+			methods[recv] = map[string]*Func{}
+		}
+		methods[recv][fn.Name.Name] = funcFromDecl(fset, fn)
+	}
+
+	if c.Constructor == nil || c.Name == "" {
+		return nil, fmt.Errorf("no Constructor found in solution")
+	}
+	c.Methods = methods[c.Name]
+	if c.Methods == nil {
+		c.Methods = map[string]*Func{}
+	}
+	return c, nil
+}
+
+// recvName 接收者的类型名: T、*T 都是 T
+func recvName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return recvName(t.X)
+	case *ast.ParenExpr:
+		return recvName(t.X)
+	case *ast.IndexExpr:
+		return recvName(t.X)
+	case *ast.IndexListExpr:
+		return recvName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// MethodNames LeetCode 能调用的方法 (导出的)，按名字排序
+// 题解里自己加的小写辅助方法不算
+func (c *Class) MethodNames() []string {
+	var names []string
+	for name := range c.Methods {
+		if r := []rune(name); unicode.IsUpper(r[0]) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OpName Go 方法名对应的 LeetCode 操作名 (GetMin -> getMin)
+func OpName(method string) string {
+	if method == "" {
+		return method
+	}
+	r := []rune(method)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
@@ -0,0 +1,54 @@
+package problem
+
+import (
+	"reflect"
+	"testing"
+)
+
+const medianFinder = `
+type hp struct{ sort.IntSlice }
+
+func (h *hp) Push(v any) { h.IntSlice = append(h.IntSlice, v.(int)) }
+func (h *hp) Pop() any {
+	a := h.IntSlice
+	v := a[len(a)-1]
+	h.IntSlice = a[:len(a)-1]
+	return v
+}
+
+type MedianFinder struct {
+	lo, hi hp
+}
+
+func Constructor() MedianFinder {
+	return MedianFinder{}
+}
+
+func (m *MedianFinder) AddNum(num int) {}
+
+func (m *MedianFinder) FindMedian() float64 { return 0 }
+
+func (m MedianFinder) balance() {}
+`
+
+func TestParseGoClassOnlyOwnMethods(t *testing.T) {
+	c, err := ParseGoClass(medianFinder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "MedianFinder" {
+		t.Errorf("Name = %q, want MedianFinder", c.Name)
+	}
+	if got, want := c.MethodNames(), []string{"AddNum", "FindMedian"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MethodNames = %v, want %v", got, want)
+	}
+	if _, ok := c.Methods["balance"]; !ok {
+		t.Errorf("unexported method of the class should still be parsed")
+	}
+}
+
+func TestParseGoClassNoConstructor(t *testing.T) {
+	if _, err := ParseGoClass("type A struct{}\nfunc (a *A) Get() int { return 0 }"); err == nil {
+		t.Error("expected an error without Constructor")
+	}
+}
@@ -0,0 +1,183 @@
+package problem
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/X-for/ltgo/internal/models"
+)
+
+// Example 描述里的一个示例 (原始文本)
+type Example struct {
+	Input  string // 如 "nums = [2,7,11,15], target = 9"
+	Output string // 如 "[0,1]"
+}
+
+// Case 一组可以直接喂给题解的测试数据
+type Case struct {
+	Args     []string // 每个参数一个 LeetCode 格式的值
+	Expected string   // 期望输出，描述里解析不到时为空
+}
+
+var (
+	reBlockTag = regexp.MustCompile(`(?i)(</p>|</div>|</pre>|</li>|<br\s*/?>)`)
+	reAnyTag   = regexp.MustCompile(`<[^>]*>`)
+	// 示例里的各种小标题，中英文都要认
+	reLabel = regexp.MustCompile(`^\s*(Input|输入|Output|输出|Explanation|解释|Example\s*\d*|示例\s*\d*|Constraints|提示|Note|注意|Follow[- ]?up|进阶)\s*[:：]?\s*(.*)$`)
+)
+
+// ParseExamples 从题目描述 HTML 里提取所有示例的输入输出
+// 每个示例一项 (和 exampleTestcases 一一对应)，解析不到输出的 Output 为空
+func ParseExamples(content string) []Example {
+	text := reBlockTag.ReplaceAllString(content, "$1\n\n")
+	text = reAnyTag.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+	text = strings.ReplaceAll(text, "\u00a0", " ") // &nbsp;
+
+	var (
+		examples []Example
+		cur      *Example
+		field    *string // 正在收集的字段 (Input / Output)
+	)
+	for _, line := range strings.Split(text, "\n") {
+		if m := reLabel.FindStringSubmatch(line); m != nil {
+			field = nil
+			switch label := strings.ToLower(m[1]); {
+			case label == "input" || label == "输入":
+				examples = append(examples, Example{})
+				cur = &examples[len(examples)-1]
+				field = &cur.Input
+			case (label == "output" || label == "输出") && cur != nil:
+				field = &cur.Output
+			}
+			if field != nil && strings.TrimSpace(m[2]) != "" {
+				*field = strings.TrimSpace(m[2])
+			}
+			continue
+		}
+
+		// 值可能在标签的下一行 (设计类题目的 Input 有两行)，空行结束
+		if field == nil {
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			if *field != "" {
+				field = nil
+			}
+			continue
+		}
+		if *field != "" {
+			*field += "\n"
+		}
+		*field += strings.TrimSpace(line)
+	}
+
+	return examples
+}
+
+// SplitInput 把 "nums = [2,7,11,15], target = 9" 按参数名切成每个参数的值
+func SplitInput(input string, names []string) ([]string, bool) {
+	if len(names) == 0 {
+		return nil, false
+	}
+
+	// 没有 "name =" 的写法: 设计类题目按行给出，单参数题有时直接给值
+	first := regexp.MustCompile(`(?:^|[\s,])` + regexp.QuoteMeta(names[0]) + `\s*=`)
+	if !first.MatchString(input) {
+		if lines := nonEmptyLines(input); len(lines) == len(names) {
+			return lines, true
+		}
+		if len(names) == 1 {
+			return []string{strings.TrimSpace(input)}, true
+		}
+		return nil, false
+	}
+
+	// 依次找到每个 "name =" 的位置，值一直到下一个 "name =" 之前
+	type span struct{ start, value int }
+	spans := make([]span, len(names))
+	pos := 0
+	for i, name := range names {
+		re := regexp.MustCompile(`(?:^|[\s,])` + regexp.QuoteMeta(name) + `\s*=\s*`)
+		loc := re.FindStringIndex(input[pos:])
+		if loc == nil {
+			return nil, false
+		}
+		spans[i] = span{start: pos + loc[0], value: pos + loc[1]}
+		pos += loc[1]
+	}
+
+	args := make([]string, len(names))
+	for i, sp := range spans {
+		end := len(input)
+		if i+1 < len(spans) {
+			end = spans[i+1].start
+		}
+		v := strings.TrimSpace(input[sp.value:end])
+		args[i] = strings.TrimSpace(strings.TrimSuffix(v, ","))
+	}
+	return args, true
+}
+
+// Cases 组装题目自带的测试数据
+// 输入优先用 exampleTestcases (和 LeetCode 的格式完全一致)，没有时再从描述里切；
+// 期望输出只能从描述里拿
+func Cases(q *models.QuestionDetail, names []string) []Case {
+	examples := ParseExamples(q.Content)
+	if len(examples) == 0 && q.TranslatedContent != "" {
+		examples = ParseExamples(q.TranslatedContent)
+	}
+
+	// 示例和输入对不上时 (描述里漏了某个示例) 没法确定期望输出是哪个输入的，干脆不要
+	inputs, ok := SplitTestcases(q.ExampleTestcases, len(names))
+	aligned := ok && len(inputs) == len(examples)
+	if !ok || len(inputs) == 0 {
+		inputs = nil
+		for _, e := range examples {
+			args, ok := SplitInput(e.Input, names)
+			if !ok {
+				break
+			}
+			inputs = append(inputs, args)
+		}
+		aligned = true
+	}
+	// 实在不行至少还有 sampleTestCase (第一个示例)
+	if len(inputs) == 0 {
+		inputs, _ = SplitTestcases(q.SampleTestCase, len(names))
+		aligned = true
+	}
+
+	cases := make([]Case, len(inputs))
+	for i, args := range inputs {
+		cases[i].Args = args
+		if aligned && i < len(examples) {
+			cases[i].Expected = examples[i].Output
+		}
+	}
+	return cases
+}
+
+// SplitTestcases 把多组输入 (每行一个参数) 按参数个数切开
+func SplitTestcases(raw string, n int) ([][]string, bool) {
+	lines := nonEmptyLines(raw)
+	if n <= 0 || len(lines)%n != 0 {
+		return nil, false
+	}
+	var out [][]string
+	for i := 0; i < len(lines); i += n {
+		out = append(out, lines[i:i+n])
+	}
+	return out, true
+}
+
+func nonEmptyLines(s string) []string {
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,43 @@
+package problem
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/X-for/ltgo/internal/models"
+)
+
+const twoExamples = `<p><strong>Example 1:</strong></p>
+<pre><strong>Input:</strong> n = 1
+<strong>Explanation:</strong> the output was lost.
+</pre>
+<p><strong>Example 2:</strong></p>
+<pre><strong>Input:</strong> n = 2
+<strong>Output:</strong> 4
+</pre>`
+
+func TestParseExamplesKeepsMissingOutput(t *testing.T) {
+	got := ParseExamples(twoExamples)
+	want := []Example{{Input: "n = 1"}, {Input: "n = 2", Output: "4"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseExamples = %+v, want %+v", got, want)
+	}
+}
+
+// 某个示例没有输出时，后面的期望输出不能错位到前一个输入上
+func TestCasesMissingOutput(t *testing.T) {
+	q := &models.QuestionDetail{Content: twoExamples, ExampleTestcases: "1\n2"}
+	got := Cases(q, []string{"n"})
+	want := []Case{{Args: []string{"1"}}, {Args: []string{"2"}, Expected: "4"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Cases = %+v, want %+v", got, want)
+	}
+
+	// 描述里少了一个示例: 对不上就不给期望输出
+	q.ExampleTestcases = "1\n2\n3"
+	for _, c := range Cases(q, []string{"n"}) {
+		if c.Expected != "" {
+			t.Errorf("Cases with 3 inputs but 2 examples: %+v has an expected output", c)
+		}
+	}
+}
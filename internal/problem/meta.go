@@ -0,0 +1,59 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// MetaParam metaData 里的一个参数
+type MetaParam struct {
+	Name string `json:"name"`
+	Type string `json:"type"` // LeetCode 的类型名，如 "integer[]", "TreeNode"
+}
+
+// Meta 题目的 metaData (接口返回的是一个 JSON 字符串)
+type Meta struct {
+	Name   string      `json:"name"` // 函数名 (普通题)
+	Params []MetaParam `json:"params"`
+	Return struct {
+		Type string `json:"type"`
+	} `json:"return"`
+	// 原地修改类的题 (如 rotate) 没有返回值，结果是第几个参数
+	Output struct {
+		ParamIndex *int `json:"paramindex"`
+	} `json:"output"`
+
+	// 设计类题目 (Min Stack / LRU Cache)
+	ClassName    string `json:"classname"`
+	SystemDesign bool   `json:"systemdesign"`
+	Manual       bool   `json:"manual"` // 交互题等，没法在本地跑
+}
+
+// ParseMeta 解析 QuestionDetail.MetaData
+func ParseMeta(s string) (*Meta, error) {
+	if s == "" {
+		return nil, errors.New("empty metaData")
+	}
+	var m Meta
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ParamNames 参数名列表
+func (m *Meta) ParamNames() []string {
+	names := make([]string, len(m.Params))
+	for i, p := range m.Params {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// Arity 一个用例占几行: 普通题是参数个数，设计类固定是 操作名 + 参数 两行
+func (m *Meta) Arity() int {
+	if m.SystemDesign || m.ClassName != "" {
+		return 2
+	}
+	return len(m.Params)
+}
@@ -0,0 +1,78 @@
+package problem
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+)
+
+// Param 函数参数
+type Param struct {
+	Name string
+	Type string // Go 类型表达式，如 "[]int", "*TreeNode"
+}
+
+// Func Go 题解里的入口函数签名
+type Func struct {
+	Name   string
+	Params []Param
+	Result string // 没有返回值时为空
+}
+
+// ParseGoFunc 从 Go 代码 (@lc code 区域) 里找出入口函数
+// name 为空时取第一个顶层函数 (不含方法)
+func ParseGoFunc(code string, name string) (*Func, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "solution.go", "package main\n"+code, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse solution: %w", err)
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil {
+			continue
+		}
+		if name != "" && fn.Name.Name != name {
+			continue
+		}
+		return funcFromDecl(fset, fn), nil
+	}
+
+	if name != "" {
+		return nil, fmt.Errorf("function '%s' not found in solution", name)
+	}
+	return nil, fmt.Errorf("no function found in solution")
+}
+
+func funcFromDecl(fset *token.FileSet, fn *ast.FuncDecl) *Func {
+	f := &Func{Name: fn.Name.Name}
+	for _, field := range fn.Type.Params.List {
+		typ := exprString(fset, field.Type)
+		for _, n := range field.Names {
+			f.Params = append(f.Params, Param{Name: n.Name, Type: typ})
+		}
+	}
+	if res := fn.Type.Results; res != nil && len(res.List) > 0 {
+		f.Result = exprString(fset, res.List[0].Type)
+	}
+	return f
+}
+
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, expr)
+	return buf.String()
+}
+
+// ParamNames 参数名列表
+func (f *Func) ParamNames() []string {
+	names := make([]string, len(f.Params))
+	for i, p := range f.Params {
+		names[i] = p.Name
+	}
+	return names
+}
@@ -0,0 +1,193 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/X-for/ltgo/internal/codec"
+)
+
+// 题解旁边的测试用例文件 (questions/1_two-sum.testcases)，格式:
+//
+//	# 注释
+//	[2,7,11,15]
+//	9
+//	=> [0,1]
+//
+//	[3,2,4]
+//	6
+//
+// 用例之间空行分隔，每行一个参数；"=>" 开头的行是期望输出 (可省略)
+
+// TestcaseExt 用例文件的扩展名
+const TestcaseExt = ".testcases"
+
+// TestcasePath 题解文件对应的用例文件路径
+func TestcasePath(solutionPath string) string {
+	return strings.TrimSuffix(solutionPath, filepath.Ext(solutionPath)) + TestcaseExt
+}
+
+// LoadTestcases 读取用例文件，文件不存在时返回空
+func LoadTestcases(path string) ([]Case, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseTestcases(string(data)), nil
+}
+
+// ParseTestcases 解析用例文件内容
+func ParseTestcases(text string) []Case {
+	var cases []Case
+	var cur Case
+	flush := func() {
+		if len(cur.Args) > 0 {
+			cases = append(cases, cur)
+		}
+		cur = Case{}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "=>"):
+			cur.Expected = strings.TrimSpace(strings.TrimPrefix(line, "=>"))
+		default:
+			cur.Args = append(cur.Args, line)
+		}
+	}
+	flush()
+	return cases
+}
+
+// ParseTestcaseArg 命令行上的一个用例: 参数之间用换行或字面的 \n 分隔
+// 字符串里的 \n (如 "a\nb") 是转义字符，不算分隔
+func ParseTestcaseArg(s string) Case {
+	var sb strings.Builder
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quoted && s[i] == '\\' && i+1 < len(s):
+			sb.WriteString(s[i : i+2])
+			i++
+			continue
+		case s[i] == '"':
+			quoted = !quoted
+		case !quoted && strings.HasPrefix(s[i:], `\n`):
+			sb.WriteByte('\n')
+			i++
+			continue
+		}
+		sb.WriteByte(s[i])
+	}
+	return Case{Args: nonEmptyLines(sb.String())}
+}
+
+// CheckCase 检查参数个数和每个参数的格式，n <= 0 时不检查个数
+func CheckCase(c Case, n int) error {
+	if n > 0 && len(c.Args) != n {
+		return fmt.Errorf("expected %d parameters, got %d", n, len(c.Args))
+	}
+	for i, arg := range c.Args {
+		if err := codec.Validate(arg); err != nil {
+			return fmt.Errorf("parameter %d %q: %w", i+1, arg, err)
+		}
+	}
+	if c.Expected != "" {
+		if err := codec.Validate(c.Expected); err != nil {
+			return fmt.Errorf("expected output %q: %w", c.Expected, err)
+		}
+	}
+	return nil
+}
+
+// DataInput 拼成 LeetCode 运行接口的 data_input (每行一个参数)
+func DataInput(cases []Case) string {
+	var lines []string
+	for _, c := range cases {
+		lines = append(lines, c.Args...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AppendTestcase 把用例追加到用例文件末尾，参数完全相同的用例已存在时不重复写
+// comment 写在用例前面 (如提交结果和时间)，返回是否真的写入了
+func AppendTestcase(path string, c Case, comment string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	for _, e := range ParseTestcases(string(data)) {
+		if sameArgs(e.Args, c.Args) {
+			return false, nil
+		}
+	}
+
+	var sb strings.Builder
+	if len(data) > 0 {
+		// 和前面的用例之间空一行
+		if data[len(data)-1] != '\n' {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	if comment != "" {
+		fmt.Fprintf(&sb, "# %s\n", comment)
+	}
+	for _, arg := range c.Args {
+		sb.WriteString(arg + "\n")
+	}
+	if c.Expected != "" {
+		fmt.Fprintf(&sb, "=> %s\n", c.Expected)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	if _, err := f.WriteString(sb.String()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// FailedCase 提交结果里出错的用例
+// lastTestcase 是原始格式 (每行一个参数)；没有时从 "[1,2], 3" 这样的 inputFormatted 里切
+func FailedCase(lastTestcase, inputFormatted, expected string) (Case, bool) {
+	c := Case{Expected: strings.TrimSpace(expected)}
+	if lines := nonEmptyLines(lastTestcase); len(lines) > 0 {
+		c.Args = lines
+	} else if inputFormatted != "" {
+		args, err := codec.Split("[" + inputFormatted + "]")
+		if err != nil {
+			return Case{}, false
+		}
+		c.Args = args
+	}
+	if len(c.Args) == 0 {
+		return Case{}, false
+	}
+	return c, true
+}
+
+func sameArgs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] && !codec.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+package problem
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTestcaseArg(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{`[1,2]\n3`, []string{"[1,2]", "3"}},
+		{"[1,2]\n3", []string{"[1,2]", "3"}},
+		{`"a\nb"`, []string{`"a\nb"`}},
+		{`"a\nb"\n"c"`, []string{`"a\nb"`, `"c"`}},
+		{`"a\\"\n1`, []string{`"a\\"`, "1"}},
+		{`[1]\n\n2`, []string{"[1]", "2"}},
+	}
+	for _, tt := range tests {
+		if got := ParseTestcaseArg(tt.in).Args; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseTestcaseArg(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/X-for/ltgo/internal/models"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// 题目描述的语言 (description_language / --desc-lang)
+// 为空时保持原来的行为: 有中文翻译就用中文，标题用英文
+const (
+	DescEnglish = "en"
+	DescChinese = "zh"
+	DescBoth    = "both" // 中英对照，按小节交替
+)
+
+// CheckDescLang 检查 description_language 的取值，空串表示默认
+func CheckDescLang(lang string) error {
+	switch lang {
+	case "", DescEnglish, DescChinese, DescBoth:
+		return nil
+	}
+	return fmt.Errorf("invalid description language '%s' (use en, zh or both)", lang)
+}
+
+// Description 按语言选出题目描述 (HTML)，选的语言没有内容时退回另一种
+func Description(q *models.QuestionDetail, lang string) string {
+	switch {
+	case q.TranslatedContent == "":
+		return q.Content
+	case q.Content == "":
+		return q.TranslatedContent
+	}
+	switch lang {
+	case DescEnglish:
+		return q.Content
+	case DescBoth:
+		return Bilingual(q.Content, q.TranslatedContent)
+	}
+	return q.TranslatedContent
+}
+
+// Title 按语言选标题，both 时是 "Two Sum (两数之和)"
+func Title(q *models.QuestionDetail, lang string) string {
+	if q.TranslatedTitle == "" || q.TranslatedTitle == q.Title {
+		return q.Title
+	}
+	switch lang {
+	case DescChinese:
+		return q.TranslatedTitle
+	case DescBoth:
+		return q.Title + " (" + q.TranslatedTitle + ")"
+	}
+	return q.Title
+}
+
+// Bilingual 把原文和译文按小节交替拼在一起: 描述、示例 1、示例 2、提示…… 每节先原文后译文
+// 小节从标题或以粗体开头的段落 (<p><strong>Example 1:</strong></p>) 开始
+// 两边小节数对不上时 (翻译改了结构)，整段原文在前、译文在后，中间用 <hr> 隔开
+func Bilingual(original, translated string) string {
+	a, errA := sections(original)
+	b, errB := sections(translated)
+	if errA != nil || errB != nil || len(a) != len(b) {
+		return original + "\n<hr>\n" + translated
+	}
+	var sb strings.Builder
+	for i := range a {
+		sb.WriteString(a[i])
+		sb.WriteString("\n")
+		sb.WriteString(b[i])
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func sections(content string) ([]string, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(content), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	var buf bytes.Buffer
+	text := ""
+	flush := func() {
+		// 只有空白的部分 (如 <p>&nbsp;</p>) 不算一节
+		if strings.TrimSpace(strings.ReplaceAll(text, "\u00a0", " ")) != "" || strings.Contains(buf.String(), "<img") {
+			out = append(out, buf.String())
+		}
+		buf.Reset()
+		text = ""
+	}
+	for _, n := range nodes {
+		if sectionStart(n) {
+			flush()
+		}
+		if err := html.Render(&buf, n); err != nil {
+			return nil, err
+		}
+		text += textContent(n)
+	}
+	flush()
+	return out, nil
+}
+
+func sectionStart(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		return true
+	case atom.P:
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.TextNode && strings.TrimSpace(strings.ReplaceAll(c.Data, " ", "")) == "" {
+				continue
+			}
+			return c.Type == html.ElementNode && (c.DataAtom == atom.Strong || c.DataAtom == atom.B)
+		}
+	}
+	return false
+}
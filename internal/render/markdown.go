@@ -0,0 +1,316 @@
+package render
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Markdown 把题目描述转成 GitHub 风格的 Markdown
+// image 把 <img> 的地址换成新的链接 (比如下载到本地后的相对路径)，为 nil 时保留原地址
+func Markdown(content string, image func(src string) string) string {
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return content
+	}
+	r := &mdRenderer{image: image}
+	return strings.Join(r.blocks(findBody(doc)), "\n\n")
+}
+
+type mdRenderer struct {
+	image     func(src string) string
+	pre       int      // >0 时在 <pre> 里，只要纯文本
+	preImages []string // <pre> 里的图片，放到代码块前面
+}
+
+func (r *mdRenderer) blocks(n *html.Node) []string {
+	var out []string
+	var inline strings.Builder
+	flush := func() {
+		if p := mdParagraph(inline.String()); p != "" {
+			out = append(out, p)
+		}
+		inline.Reset()
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && blockAtoms[c.DataAtom] {
+			flush()
+			out = append(out, r.block(c)...)
+			continue
+		}
+		inline.WriteString(r.inline(c))
+	}
+	flush()
+	return out
+}
+
+func (r *mdRenderer) block(n *html.Node) []string {
+	switch n.DataAtom {
+	case atom.Pre:
+		r.pre++
+		text := strings.Trim(r.children(n), "\n")
+		r.pre--
+		var out []string
+		if len(r.preImages) > 0 {
+			out = append(out, strings.Join(r.preImages, "\n"))
+			r.preImages = nil
+		}
+		if strings.TrimSpace(text) != "" {
+			fence := "```"
+			for strings.Contains(text, fence) {
+				fence += "`"
+			}
+			out = append(out, fence+"\n"+trimLines(text)+"\n"+fence)
+		}
+		return out
+	case atom.Div:
+		if hasClass(n, "example-block") {
+			return nonEmpty(blockquote(strings.Join(r.blocks(n), "\n\n")))
+		}
+		return r.blocks(n)
+	case atom.Blockquote:
+		return nonEmpty(blockquote(strings.Join(r.blocks(n), "\n\n")))
+	case atom.Ul, atom.Ol:
+		return nonEmpty(r.list(n))
+	case atom.Table:
+		return nonEmpty(r.table(n))
+	case atom.Hr:
+		return []string{"---"}
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		if text := strings.ReplaceAll(mdParagraph(r.children(n)), "\n", " "); text != "" {
+			return []string{strings.Repeat("#", level) + " " + text}
+		}
+		return nil
+	}
+	return r.blocks(n)
+}
+
+func (r *mdRenderer) inline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		text := strings.ReplaceAll(n.Data, "\u00a0", " ")
+		if r.pre > 0 {
+			return text
+		}
+		return mdEscape(Math(spaces.ReplaceAllString(text, " ")))
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	if n.DataAtom == atom.Img {
+		src := attr(n, "src")
+		if r.image != nil {
+			src = r.image(src)
+		}
+		img := "![" + attr(n, "alt") + "](" + src + ")"
+		if r.pre > 0 {
+			r.preImages = append(r.preImages, img)
+			return ""
+		}
+		return img
+	}
+	if r.pre > 0 {
+		if n.DataAtom == atom.Br {
+			return "\n"
+		}
+		return r.children(n)
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		return "  \n"
+	case atom.Strong, atom.B:
+		return wrap("**", r.children(n))
+	case atom.Em, atom.I:
+		return wrap("*", r.children(n))
+	case atom.Code:
+		// 代码里带上标、下标 (如 10<sup>4</sup>) 时 Markdown 的 `` 表示不了，保留 HTML
+		if hasElementChild(n) {
+			var buf bytes.Buffer
+			if err := html.Render(&buf, n); err == nil {
+				return buf.String()
+			}
+		}
+		return codeSpan(textContent(n))
+	case atom.Sup, atom.Sub:
+		return "<" + n.Data + ">" + r.children(n) + "</" + n.Data + ">"
+	case atom.A:
+		text := r.children(n)
+		if href := attr(n, "href"); href != "" && strings.TrimSpace(text) != "" {
+			return "[" + text + "](" + href + ")"
+		}
+		return text
+	case atom.Script, atom.Style:
+		return ""
+	}
+	if blockAtoms[n.DataAtom] {
+		return "  \n" + strings.Join(r.blocks(n), "  \n") + "  \n"
+	}
+	return r.children(n)
+}
+
+func (r *mdRenderer) children(n *html.Node) string {
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(r.inline(c))
+	}
+	return sb.String()
+}
+
+func (r *mdRenderer) list(n *html.Node) string {
+	var lines []string
+	i := 0
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.DataAtom != atom.Li {
+			continue
+		}
+		i++
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = strconv.Itoa(i) + ". "
+		}
+		pad := strings.Repeat(" ", len(marker))
+		for j, line := range strings.Split(strings.Join(r.blocks(li), "\n"), "\n") {
+			if j == 0 {
+				line = marker + line
+			} else if line != "" {
+				line = pad + line
+			}
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// table GitHub 的表格必须有表头，没有 <th> 时把第一行当表头
+func (r *mdRenderer) table(n *html.Node) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Tr {
+			var row []string
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if c.Type == html.ElementNode && (c.DataAtom == atom.Td || c.DataAtom == atom.Th) {
+					cell := strings.ReplaceAll(mdParagraph(r.children(c)), "\n", " ")
+					row = append(row, strings.ReplaceAll(cell, "|", `\|`))
+				}
+			}
+			rows = append(rows, row)
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+	line := func(row []string) string {
+		cells := make([]string, cols)
+		copy(cells, row)
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+	lines := []string{line(rows[0]), "|" + strings.Repeat(" --- |", cols)}
+	for _, row := range rows[1:] {
+		lines = append(lines, line(row))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// mdEscape 转义正文里会被当成 Markdown 语法的字符
+var mdEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "<", "&lt;")
+
+func mdEscape(s string) string {
+	return mdEscaper.Replace(s)
+}
+
+// wrap 加上强调符号，首尾空格挪到符号外面 ("** a**" 不会被当成粗体)
+func wrap(mark, s string) string {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return s
+	}
+	lead := s[:len(s)-len(strings.TrimLeft(s, " "))]
+	trail := s[len(strings.TrimRight(s, " ")):]
+	return lead + mark + trimmed + mark + trail
+}
+
+func codeSpan(s string) string {
+	s = spaces.ReplaceAllString(s, " ")
+	if !strings.Contains(s, "`") {
+		return "`" + s + "`"
+	}
+	return "`` " + s + " ``"
+}
+
+func blockquote(text string) string {
+	if strings.TrimSpace(text) == "" {
+		return ""
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("> "+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// mdParagraph 和 paragraph 一样整理空白，但保留行尾的两个空格 (Markdown 的换行)
+func mdParagraph(s string) string {
+	lines := strings.Split(s, "\n")
+	var out []string
+	for i, line := range lines {
+		hard := strings.HasSuffix(line, "  ") && i < len(lines)-1
+		line = strings.TrimSpace(manySpaces.ReplaceAllString(line, " "))
+		if line == "" {
+			continue
+		}
+		if hard {
+			line += "  "
+		}
+		out = append(out, line)
+	}
+	if len(out) > 0 {
+		out[len(out)-1] = strings.TrimRight(out[len(out)-1], " ")
+	}
+	return strings.Join(out, "\n")
+}
+
+func trimLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return strings.ReplaceAll(n.Data, "\u00a0", " ")
+	}
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(textContent(c))
+	}
+	return sb.String()
+}
+
+func hasElementChild(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode {
+			return true
+		}
+	}
+	return false
+}
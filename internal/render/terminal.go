@@ -0,0 +1,377 @@
+// Package render 把 LeetCode 题目描述 (HTML) 转成终端里可读的文本
+package render
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Options 渲染选项
+type Options struct {
+	Color bool // 输出 ANSI 粗体、颜色 (终端里才开)
+}
+
+const (
+	reset     = "\x1b[0m"
+	bold      = "\x1b[1m"
+	dim       = "\x1b[2m"
+	italic    = "\x1b[3m"
+	underline = "\x1b[4m"
+	cyan      = "\x1b[36m"
+	green     = "\x1b[32m"
+	yellow    = "\x1b[33m"
+	red       = "\x1b[31m"
+)
+
+// Bold 粗体 (不开颜色时原样返回)
+func (o Options) Bold(s string) string {
+	return o.paint(bold, s)
+}
+
+// Dim 暗色，用于次要信息
+func (o Options) Dim(s string) string {
+	return o.paint(dim, s)
+}
+
+// Difficulty 难度按 Easy 绿 / Medium 黄 / Hard 红上色
+func (o Options) Difficulty(d string) string {
+	switch strings.ToLower(d) {
+	case "easy":
+		return o.paint(green, d)
+	case "medium":
+		return o.paint(yellow, d)
+	case "hard":
+		return o.paint(red, d)
+	}
+	return d
+}
+
+func (o Options) paint(code, s string) string {
+	if !o.Color || s == "" {
+		return s
+	}
+	return code + s + reset
+}
+
+// Terminal 渲染题目描述: 段落、粗体、行内代码、示例块、列表和表格
+// 解析失败时原样返回
+func Terminal(content string, opts Options) string {
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return content
+	}
+	r := &termRenderer{opts: opts}
+	return strings.Join(r.blocks(findBody(doc)), "\n\n")
+}
+
+func findBody(n *html.Node) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == atom.Body {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if b := findBody(c); b != nil {
+			return b
+		}
+	}
+	if n.Type == html.DocumentNode {
+		return n
+	}
+	return nil
+}
+
+type termRenderer struct {
+	opts   Options
+	styles []string // 当前生效的 ANSI 样式，关闭一层后要把外层的重新打开
+	pre    int      // >0 时在 <pre> 里，保留空白
+}
+
+// blockAtoms 按块处理的元素，其余都当行内元素拼进段落
+var blockAtoms = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Pre: true, atom.Ul: true, atom.Ol: true,
+	atom.Table: true, atom.Blockquote: true, atom.Hr: true, atom.Section: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+}
+
+// blocks 渲染 n 的子节点，返回一个个块 (调用方用空行隔开)
+func (r *termRenderer) blocks(n *html.Node) []string {
+	var out []string
+	var inline strings.Builder
+	flush := func() {
+		if p := paragraph(inline.String()); p != "" {
+			out = append(out, p)
+		}
+		inline.Reset()
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && blockAtoms[c.DataAtom] {
+			flush()
+			out = append(out, r.block(c)...)
+			continue
+		}
+		inline.WriteString(r.inline(c))
+	}
+	flush()
+	return out
+}
+
+func (r *termRenderer) block(n *html.Node) []string {
+	switch n.DataAtom {
+	case atom.Pre:
+		r.pre++
+		text := r.children(n)
+		r.pre--
+		return nonEmpty(r.quote(strings.Trim(text, "\n")))
+	case atom.Div:
+		// 新版题面的示例是 <div class="example-block">
+		if hasClass(n, "example-block") {
+			return nonEmpty(r.quote(strings.Join(r.blocks(n), "\n")))
+		}
+		return r.blocks(n)
+	case atom.Blockquote:
+		return nonEmpty(r.quote(strings.Join(r.blocks(n), "\n")))
+	case atom.Ul, atom.Ol:
+		return nonEmpty(r.list(n))
+	case atom.Table:
+		return nonEmpty(r.table(n))
+	case atom.Hr:
+		return []string{r.opts.Dim(strings.Repeat("─", 40))}
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		return nonEmpty(paragraph(r.styled(bold+underline, n)))
+	}
+	return r.blocks(n)
+}
+
+// inline 渲染行内内容，空白按 HTML 规则合并 (<pre> 里除外)
+func (r *termRenderer) inline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		text := strings.ReplaceAll(n.Data, "\u00a0", " ")
+		if r.pre > 0 {
+			return text
+		}
+		return Math(spaces.ReplaceAllString(text, " "))
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		return "\n"
+	case atom.Strong, atom.B:
+		return r.styled(bold, n)
+	case atom.Em, atom.I:
+		return r.styled(italic, n)
+	case atom.U:
+		return r.styled(underline, n)
+	case atom.Code:
+		if !r.opts.Color && r.pre == 0 {
+			return "`" + r.children(n) + "`"
+		}
+		return r.styled(cyan, n)
+	case atom.Sup:
+		return Superscript(stripANSI(r.children(n)))
+	case atom.Sub:
+		return Subscript(stripANSI(r.children(n)))
+	case atom.Img:
+		label := attr(n, "alt")
+		if label == "" {
+			label = attr(n, "src")
+		}
+		return "[image: " + label + "]"
+	case atom.Script, atom.Style:
+		return ""
+	}
+	if blockAtoms[n.DataAtom] {
+		// 表格单元格、标题里的块元素，按行处理
+		return "\n" + strings.Join(r.blocks(n), "\n") + "\n"
+	}
+	return r.children(n)
+}
+
+func (r *termRenderer) children(n *html.Node) string {
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(r.inline(c))
+	}
+	return sb.String()
+}
+
+// styled 用 ANSI 样式包住 n 的内容，结束后恢复外层样式
+func (r *termRenderer) styled(code string, n *html.Node) string {
+	if !r.opts.Color {
+		return r.children(n)
+	}
+	r.styles = append(r.styles, code)
+	text := r.children(n)
+	r.styles = r.styles[:len(r.styles)-1]
+	return code + text + reset + strings.Join(r.styles, "")
+}
+
+// quote 示例块: 每行前面加竖线
+func (r *termRenderer) quote(text string) string {
+	if strings.TrimSpace(stripANSI(text)) == "" {
+		return ""
+	}
+	bar := r.opts.Dim("│") + " "
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = "  " + bar + strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r *termRenderer) list(n *html.Node) string {
+	var lines []string
+	i := 0
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.DataAtom != atom.Li {
+			continue
+		}
+		i++
+		marker := "• "
+		if n.DataAtom == atom.Ol {
+			marker = strconv.Itoa(i) + ". "
+		}
+		pad := strings.Repeat(" ", runewidth.StringWidth(marker))
+		for j, line := range strings.Split(strings.Join(r.blocks(li), "\n"), "\n") {
+			if j == 0 {
+				line = marker + line
+			} else if line != "" {
+				line = pad + line
+			}
+			lines = append(lines, "  "+line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// table 用制表符画框，列宽按显示宽度算 (中文占两格)
+func (r *termRenderer) table(n *html.Node) string {
+	var rows [][]string
+	header := false
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Tr {
+			var row []string
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if c.Type != html.ElementNode || (c.DataAtom != atom.Td && c.DataAtom != atom.Th) {
+					continue
+				}
+				if c.DataAtom == atom.Th && len(rows) == 0 {
+					header = true
+				}
+				row = append(row, strings.ReplaceAll(paragraph(r.children(c)), "\n", " "))
+			}
+			rows = append(rows, row)
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], displayWidth(cell))
+		}
+	}
+
+	rule := func(left, mid, right string) string {
+		parts := make([]string, len(widths))
+		for i, w := range widths {
+			parts[i] = strings.Repeat("─", w+2)
+		}
+		return "  " + left + strings.Join(parts, mid) + right
+	}
+	var lines []string
+	lines = append(lines, rule("┌", "┬", "┐"))
+	for i, row := range rows {
+		var sb strings.Builder
+		sb.WriteString("  │")
+		for j, w := range widths {
+			cell := ""
+			if j < len(row) {
+				cell = row[j]
+			}
+			if i == 0 && header {
+				cell = r.opts.Bold(stripANSI(cell))
+			}
+			sb.WriteString(" " + cell + strings.Repeat(" ", w-displayWidth(cell)) + " │")
+		}
+		lines = append(lines, sb.String())
+		if i == 0 && header && len(rows) > 1 {
+			lines = append(lines, rule("├", "┼", "┤"))
+		}
+	}
+	lines = append(lines, rule("└", "┴", "┘"))
+	return strings.Join(lines, "\n")
+}
+
+var (
+	spaces     = regexp.MustCompile(`[ \t\r\n\f]+`)
+	manySpaces = regexp.MustCompile(` {2,}`)
+	ansi       = regexp.MustCompile("\x1b\\[[0-9;]*m")
+)
+
+// paragraph 整理一段行内文本: 去掉每行首尾空白和多余空格，丢掉开头结尾的空行
+func paragraph(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(manySpaces.ReplaceAllString(line, " "))
+	}
+	for len(lines) > 0 && stripANSI(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && stripANSI(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func stripANSI(s string) string {
+	return ansi.ReplaceAllString(s, "")
+}
+
+func displayWidth(s string) int {
+	return runewidth.StringWidth(stripANSI(s))
+}
+
+func nonEmpty(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return []string{s}
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(attr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,266 @@
+package render
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Unicode 上标、下标字符，不是每个字符都有
+var (
+	superscripts = map[rune]rune{
+		'0': '⁰', '1': '¹', '2': '²', '3': '³', '4': '⁴', '5': '⁵', '6': '⁶', '7': '⁷', '8': '⁸', '9': '⁹',
+		'+': '⁺', '-': '⁻', '=': '⁼', '(': '⁽', ')': '⁾',
+		'a': 'ᵃ', 'b': 'ᵇ', 'c': 'ᶜ', 'd': 'ᵈ', 'e': 'ᵉ', 'f': 'ᶠ', 'g': 'ᵍ', 'h': 'ʰ', 'i': 'ⁱ', 'j': 'ʲ',
+		'k': 'ᵏ', 'l': 'ˡ', 'm': 'ᵐ', 'n': 'ⁿ', 'o': 'ᵒ', 'p': 'ᵖ', 'r': 'ʳ', 's': 'ˢ', 't': 'ᵗ', 'u': 'ᵘ',
+		'v': 'ᵛ', 'w': 'ʷ', 'x': 'ˣ', 'y': 'ʸ', 'z': 'ᶻ',
+	}
+	subscripts = map[rune]rune{
+		'0': '₀', '1': '₁', '2': '₂', '3': '₃', '4': '₄', '5': '₅', '6': '₆', '7': '₇', '8': '₈', '9': '₉',
+		'+': '₊', '-': '₋', '=': '₌', '(': '₍', ')': '₎',
+		'a': 'ₐ', 'e': 'ₑ', 'h': 'ₕ', 'i': 'ᵢ', 'j': 'ⱼ', 'k': 'ₖ', 'l': 'ₗ', 'm': 'ₘ', 'n': 'ₙ', 'o': 'ₒ',
+		'p': 'ₚ', 'r': 'ᵣ', 's': 'ₛ', 't': 'ₜ', 'u': 'ᵤ', 'v': 'ᵥ', 'x': 'ₓ',
+	}
+)
+
+// Superscript 10<sup>4</sup> -> 10⁴；有字符没有对应的上标时退回 10^4 / 2^(n-1)
+func Superscript(s string) string {
+	return script(s, superscripts, "^")
+}
+
+// Subscript nums<sub>i</sub> -> numsᵢ；退回写法是 nums_i / a_(i,j)
+func Subscript(s string) string {
+	return script(s, subscripts, "_")
+}
+
+func script(s string, table map[rune]rune, mark string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	var sb strings.Builder
+	for _, r := range s {
+		m, ok := table[r]
+		if !ok {
+			return fallback(s, mark)
+		}
+		sb.WriteRune(m)
+	}
+	return sb.String()
+}
+
+func fallback(s, mark string) string {
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return mark + "(" + s + ")"
+		}
+	}
+	return mark + s
+}
+
+// latexSymbols 题面里常见的 LaTeX 命令
+var latexSymbols = map[string]string{
+	"le": "≤", "leq": "≤", "ge": "≥", "geq": "≥", "ne": "≠", "neq": "≠", "lt": "<", "gt": ">",
+	"times": "×", "cdot": "·", "div": "÷", "pm": "±", "infty": "∞", "approx": "≈",
+	"ldots": "…", "dots": "…", "cdots": "⋯", "in": "∈", "notin": "∉", "subseteq": "⊆",
+	"to": "→", "rightarrow": "→", "leftarrow": "←", "Rightarrow": "⇒", "iff": "⇔",
+	"lfloor": "⌊", "rfloor": "⌋", "lceil": "⌈", "rceil": "⌉", "mid": "|", "vert": "|", "lvert": "|", "rvert": "|",
+	"sum": "Σ", "prod": "Π", "sqrt": "√", "oplus": "⊕", "land": "∧", "lor": "∨", "neg": "¬", "wedge": "∧", "vee": "∨",
+	"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ", "epsilon": "ε", "theta": "θ", "lambda": "λ",
+	"mu": "μ", "pi": "π", "sigma": "σ", "phi": "φ", "omega": "ω", "Delta": "Δ", "Sigma": "Σ",
+	"log": "log", "ln": "ln", "min": "min", "max": "max", "gcd": "gcd", "lcm": "lcm", "bmod": "mod", "mod": "mod",
+	"left": "", "right": "", "displaystyle": "", "quad": " ", "qquad": "  ",
+	",": " ", ";": " ", " ": " ", "!": "", "{": "{", "}": "}", "%": "%", "$": "$", "_": "_", "#": "#", "&": "&",
+}
+
+var (
+	// 题面里的行内公式: $$...$$、\(...\)、\[...\]
+	reMath      = regexp.MustCompile(`\$\$(.+?)\$\$|\\\((.+?)\\\)|\\\[(.+?)\\\]`)
+	reTextCmd   = regexp.MustCompile(`\\(?:text|mathrm|mathbf|mathit|texttt|operatorname)\s*\{([^{}]*)\}`)
+	reFrac      = regexp.MustCompile(`\\[dt]?frac\s*\{([^{}]*)\}\s*\{([^{}]*)\}`)
+	reSqrt      = regexp.MustCompile(`\\sqrt\s*\{([^{}]*)\}`)
+	reScript    = regexp.MustCompile(`([\^_])(?:\{([^{}]*)\}|(\\?[A-Za-z0-9]))`)
+	reCommand   = regexp.MustCompile(`\\([A-Za-z]+|[,;! {}%$_#&])`)
+	reMathSpace = regexp.MustCompile(`[ \t]{2,}`)
+)
+
+// Math 把文本里的 LaTeX 行内公式换成可读的 Unicode，如 $$1 \le n \le 10^5$$ -> 1 ≤ n ≤ 10⁵
+func Math(s string) string {
+	if !strings.ContainsAny(s, `$\`) {
+		return s
+	}
+	return reMath.ReplaceAllStringFunc(s, func(m string) string {
+		sub := reMath.FindStringSubmatch(m)
+		return latex(sub[1] + sub[2] + sub[3])
+	})
+}
+
+// latex 转换一个公式的内容
+func latex(s string) string {
+	s = reTextCmd.ReplaceAllString(s, "$1")
+	s = reFrac.ReplaceAllStringFunc(s, func(m string) string {
+		sub := reFrac.FindStringSubmatch(m)
+		return group(sub[1]) + "/" + group(sub[2])
+	})
+	s = reSqrt.ReplaceAllStringFunc(s, func(m string) string {
+		return "√" + group(reSqrt.FindStringSubmatch(m)[1])
+	})
+	s = reScript.ReplaceAllStringFunc(s, func(m string) string {
+		sub := reScript.FindStringSubmatch(m)
+		body := sub[2] + sub[3]
+		if strings.HasPrefix(body, `\`) {
+			body = symbols(body)
+		}
+		if sub[1] == "^" {
+			return Superscript(symbols(body))
+		}
+		return Subscript(symbols(body))
+	})
+	s = symbols(s)
+	s = strings.NewReplacer("{", "", "}", "").Replace(s)
+	return strings.TrimSpace(reMathSpace.ReplaceAllString(s, " "))
+}
+
+func symbols(s string) string {
+	return reCommand.ReplaceAllStringFunc(s, func(m string) string {
+		if sym, ok := latexSymbols[m[1:]]; ok {
+			return sym
+		}
+		return m[1:]
+	})
+}
+
+// group 多个字符的分子分母加括号: (n+1)/2
+func group(s string) string {
+	s = strings.TrimSpace(symbols(s))
+	if len([]rune(s)) > 1 && strings.ContainsAny(s, "+-*/ ") {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// Normalize 在 HTML 转成纯文本之前处理上标、下标和公式，
+// 否则 10<sup>4</sup> 会变成 104，nums<sub>i</sub> 变成 numsi
+func Normalize(content string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(content), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return content
+	}
+	var buf bytes.Buffer
+	for _, n := range nodes {
+		normalizeNode(n)
+		if err := html.Render(&buf, n); err != nil {
+			return content
+		}
+	}
+	return buf.String()
+}
+
+func normalizeNode(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch {
+		case c.Type == html.TextNode:
+			c.Data = Math(c.Data)
+		case c.Type == html.ElementNode && (c.DataAtom == atom.Sup || c.DataAtom == atom.Sub):
+			text := Math(textContent(c))
+			if c.DataAtom == atom.Sup {
+				text = Superscript(text)
+			} else {
+				text = Subscript(text)
+			}
+			// 换成文本节点，子节点不用再处理
+			*c = html.Node{Type: html.TextNode, Data: text, Parent: c.Parent, PrevSibling: c.PrevSibling, NextSibling: c.NextSibling}
+		default:
+			normalizeNode(c)
+		}
+	}
+}
+
+// Wrap 把过长的行按显示宽度折行 (中文占两格)，width <= 0 时不折
+// 续行保持原来的缩进，列表项多缩进到正文对齐；表格和示例这类排版好的行不动
+func Wrap(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	var out []string
+	for _, line := range lines {
+		if runewidth.StringWidth(line) <= width || preformatted(line) {
+			out = append(out, line)
+			continue
+		}
+		out = append(out, wrapLine(line, width)...)
+	}
+	return strings.Join(out, "\n")
+}
+
+var reListItem = regexp.MustCompile(`^(\s*)([*•-]|\d+\.)\s+`)
+
+func preformatted(line string) bool {
+	t := strings.TrimSpace(line)
+	return strings.HasPrefix(t, "|") || strings.HasPrefix(t, "+-") || strings.HasPrefix(t, "│") || strings.HasPrefix(t, "┌")
+}
+
+func wrapLine(line string, width int) []string {
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	cont := indent
+	if m := reListItem.FindString(line); m != "" {
+		cont = strings.Repeat(" ", runewidth.StringWidth(m))
+	}
+
+	var out []string
+	rest := line
+	prefix := ""
+	for {
+		avail := width - runewidth.StringWidth(prefix)
+		if runewidth.StringWidth(rest) <= avail || avail <= 10 {
+			out = append(out, prefix+rest)
+			return out
+		}
+		cut := breakPoint(rest, avail)
+		out = append(out, prefix+strings.TrimRight(rest[:cut], " "))
+		rest = strings.TrimLeft(rest[cut:], " ")
+		prefix = cont
+		if rest == "" {
+			return out
+		}
+	}
+}
+
+// breakPoint 在不超过 avail 宽度的范围内找最后一个可以断开的位置:
+// 空格处，或者两个中文字符之间；找不到时硬断
+func breakPoint(s string, avail int) int {
+	w, last, hard := 0, -1, len(s)
+	prevWide := false
+	for i, r := range s {
+		rw := runewidth.RuneWidth(r)
+		if w+rw > avail {
+			hard = i
+			break
+		}
+		if r == ' ' && i > 0 {
+			last = i
+		} else if rw == 2 && prevWide {
+			last = i
+		}
+		prevWide = rw == 2
+		w += rw
+	}
+	if hard == len(s) {
+		return len(s)
+	}
+	if last > 0 {
+		return last
+	}
+	if hard == 0 {
+		_, size := utf8.DecodeRuneInString(s)
+		return size
+	}
+	return hard
+}
@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Item 选择列表里的一项
+type Item struct {
+	Label   string // 列表里显示的一行
+	Preview string // 光标停在这一项时显示在列表下面，如难度和状态
+	Filter  string // 输入过滤时匹配的文本，为空时用 Label
+}
+
+const (
+	reverse = "\x1b[7m"
+	faint   = "\x1b[2m"
+	reset   = "\x1b[0m"
+)
+
+// Pick 显示选择列表，返回选中项在 items 里的下标，取消时返回 ErrCancelled
+// ↑/↓ (或 Ctrl-P/Ctrl-N) 移动，回车确认；直接输入字符过滤，
+// 输入框为空时按数字键 1-9 直接选当前页的第几项
+func Pick(prompt string, items []Item) (int, error) {
+	s, err := Open()
+	if err != nil {
+		return -1, err
+	}
+	defer s.Close()
+
+	p := &picker{prompt: prompt, items: items}
+	p.filter()
+	for {
+		s.Draw(p.view(s))
+		ev, err := s.ReadKey()
+		if err != nil {
+			return -1, err
+		}
+		switch ev.Key {
+		case KeyEsc, KeyCtrlC:
+			return -1, ErrCancelled
+		case KeyEnter:
+			if len(p.matches) > 0 {
+				return p.matches[p.cursor], nil
+			}
+		case KeyUp:
+			p.move(-1)
+		case KeyDown, KeyTab:
+			p.move(1)
+		case KeyPageUp:
+			p.move(-p.height(s))
+		case KeyPageDown:
+			p.move(p.height(s))
+		case KeyHome:
+			p.move(-len(p.matches))
+		case KeyEnd:
+			p.move(len(p.matches))
+		case KeyBackspace:
+			if len(p.query) > 0 {
+				p.query = p.query[:len(p.query)-1]
+				p.filter()
+			}
+		case KeyRune:
+			if len(p.query) == 0 && ev.Rune >= '1' && ev.Rune <= '9' {
+				if i := p.offset + int(ev.Rune-'1'); i < len(p.matches) && i < p.offset+p.height(s) {
+					return p.matches[i], nil
+				}
+				continue
+			}
+			p.query = append(p.query, ev.Rune)
+			p.filter()
+		}
+	}
+}
+
+type picker struct {
+	prompt  string
+	items   []Item
+	query   []rune
+	matches []int // 过滤后剩下的项 (items 的下标)
+	cursor  int   // 光标在 matches 里的位置
+	offset  int   // 当前页第一项在 matches 里的位置
+}
+
+// filter 按输入的关键词过滤，多个词都要出现，不分大小写
+func (p *picker) filter() {
+	terms := strings.Fields(strings.ToLower(string(p.query)))
+	p.matches = p.matches[:0]
+	for i, it := range p.items {
+		text := it.Filter
+		if text == "" {
+			text = it.Label
+		}
+		text = strings.ToLower(text)
+		ok := true
+		for _, t := range terms {
+			if !strings.Contains(text, t) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			p.matches = append(p.matches, i)
+		}
+	}
+	p.cursor, p.offset = 0, 0
+}
+
+func (p *picker) move(delta int) {
+	if len(p.matches) == 0 {
+		return
+	}
+	p.cursor = min(max(p.cursor+delta, 0), len(p.matches)-1)
+}
+
+// height 一页显示几项: 最多 9 项 (数字键能选到)，终端矮时更少
+func (p *picker) height(s *Screen) int {
+	_, h := s.Size()
+	return max(min(9, h-4), 1)
+}
+
+func (p *picker) view(s *Screen) []string {
+	height := p.height(s)
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	} else if p.cursor >= p.offset+height {
+		p.offset = p.cursor - height + 1
+	}
+
+	lines := []string{fmt.Sprintf("? %s %s%s", p.prompt, string(p.query), reverse+" "+reset)}
+	if len(p.matches) == 0 {
+		lines = append(lines, faint+"  (no matches)"+reset)
+	}
+	end := min(p.offset+height, len(p.matches))
+	for i := p.offset; i < end; i++ {
+		label := p.items[p.matches[i]].Label
+		if i == p.cursor {
+			lines = append(lines, fmt.Sprintf("❯ %d. %s", i-p.offset+1, reverse+label+reset))
+		} else {
+			lines = append(lines, fmt.Sprintf("  %s%d.%s %s", faint, i-p.offset+1, reset, label))
+		}
+	}
+
+	preview := ""
+	if len(p.matches) > 0 {
+		preview = p.items[p.matches[p.cursor]].Preview
+	}
+	lines = append(lines, "  "+preview)
+	lines = append(lines, faint+fmt.Sprintf("  %d/%d · ↑/↓ move · 1-9 pick · type to filter · enter select · esc cancel", len(p.matches), len(p.items))+reset)
+	return lines
+}
@@ -0,0 +1,322 @@
+// Package tui 终端里的交互界面 (选择列表、全屏浏览)，只用 ANSI 转义序列和 raw 模式
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// ErrCancelled 用户按 Esc / Ctrl-C 取消
+var ErrCancelled = errors.New("cancelled")
+
+// Interactive stdin 和 stdout 都是终端时才能用交互界面
+func Interactive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Key 按键
+type Key int
+
+const (
+	KeyRune Key = iota // 普通字符，见 Event.Rune
+	KeyEnter
+	KeyEsc
+	KeyBackspace
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyPageUp
+	KeyPageDown
+	KeyHome
+	KeyEnd
+	KeyTab
+	KeyCtrlC
+	KeyUnknown
+)
+
+// Event 一次按键
+type Event struct {
+	Key  Key
+	Rune rune
+}
+
+// Screen raw 模式下的终端
+// 普通模式在光标所在位置往下画，每次重画先回到上次的起点；全屏模式用备用屏幕，退出后恢复原来的内容
+type Screen struct {
+	fd    int
+	state *term.State
+	in    *bufio.Reader
+	out   io.Writer
+	full  bool
+	lines int // 上次画了几行 (普通模式)
+}
+
+// Open 进入 raw 模式，在当前位置画界面
+func Open() (*Screen, error) {
+	return open(false)
+}
+
+// OpenFullscreen 进入 raw 模式并切到备用屏幕
+func OpenFullscreen() (*Screen, error) {
+	return open(true)
+}
+
+func open(full bool) (*Screen, error) {
+	s := &Screen{fd: int(os.Stdin.Fd()), in: bufio.NewReader(os.Stdin), out: os.Stdout, full: full}
+	if err := s.Resume(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close 清掉画过的内容，恢复光标和终端模式
+func (s *Screen) Close() {
+	s.Suspend()
+}
+
+// Suspend 暂时还原终端 (比如要运行 less 或打印普通输出)，之后用 Resume 回来
+func (s *Screen) Suspend() {
+	if s.state == nil {
+		return
+	}
+	if s.full {
+		fmt.Fprint(s.out, "\x1b[?25h\x1b[?1049l")
+	} else {
+		s.clear()
+		fmt.Fprint(s.out, "\x1b[?25h")
+	}
+	term.Restore(s.fd, s.state)
+	s.state = nil
+}
+
+// Resume 重新进入 raw 模式 (并切到备用屏幕)，隐藏光标
+func (s *Screen) Resume() error {
+	state, err := term.MakeRaw(s.fd)
+	if err != nil {
+		return err
+	}
+	s.state = state
+	s.lines = 0
+	if s.full {
+		fmt.Fprint(s.out, "\x1b[?1049h")
+	}
+	fmt.Fprint(s.out, "\x1b[?25l")
+	return nil
+}
+
+// Interruptible 临时回到普通模式执行 f (比如联网请求)，界面和备用屏幕保持不动
+// raw 模式下 Ctrl-C 只是一个按键，同步执行的耗时操作没法打断；普通模式下它照常发出 SIGINT
+func (s *Screen) Interruptible(f func()) error {
+	if s.state == nil {
+		f()
+		return nil
+	}
+	term.Restore(s.fd, s.state)
+	f()
+	state, err := term.MakeRaw(s.fd)
+	if err != nil {
+		s.state = nil
+		return err
+	}
+	s.state = state
+	return nil
+}
+
+// Size 终端的宽和高，拿不到时按 80x24
+func (s *Screen) Size() (width, height int) {
+	w, h, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || w <= 0 || h <= 0 {
+		return 80, 24
+	}
+	return w, h
+}
+
+func (s *Screen) clear() {
+	if s.lines > 1 {
+		fmt.Fprintf(s.out, "\x1b[%dA", s.lines-1)
+	}
+	fmt.Fprint(s.out, "\r\x1b[J")
+	s.lines = 0
+}
+
+// Draw 重画整个界面: 每行按终端宽度截断，避免自动换行把行数算错
+func (s *Screen) Draw(lines []string) {
+	width, height := s.Size()
+	if s.full {
+		if len(lines) > height {
+			lines = lines[:height]
+		}
+		fmt.Fprint(s.out, "\x1b[H")
+	} else {
+		s.clear()
+	}
+	var sb strings.Builder
+	for i, line := range lines {
+		if i > 0 {
+			sb.WriteString("\r\n")
+		}
+		sb.WriteString(Truncate(line, width-1))
+		sb.WriteString("\x1b[K")
+	}
+	sb.WriteString("\x1b[J")
+	fmt.Fprint(s.out, sb.String())
+	s.lines = len(lines)
+}
+
+// Wait 在 Suspend 之后等用户按回车，再回到界面
+func (s *Screen) Wait(prompt string) {
+	fmt.Fprint(s.out, prompt)
+	s.in.ReadString('\n')
+}
+
+// Pending 还有没处理的按键 (比如连续打字)，调用方可以先不做耗时的刷新
+func (s *Screen) Pending() bool {
+	return s.in.Buffered() > 0
+}
+
+// ReadKey 读一个按键，方向键等转义序列合成一个 Event
+func (s *Screen) ReadKey() (Event, error) {
+	r, _, err := s.in.ReadRune()
+	if err != nil {
+		return Event{}, err
+	}
+	switch r {
+	case '\r', '\n':
+		return Event{Key: KeyEnter}, nil
+	case 3:
+		return Event{Key: KeyCtrlC}, nil
+	case 127, 8:
+		return Event{Key: KeyBackspace}, nil
+	case '\t':
+		return Event{Key: KeyTab}, nil
+	case 16: // Ctrl-P
+		return Event{Key: KeyUp}, nil
+	case 14: // Ctrl-N
+		return Event{Key: KeyDown}, nil
+	case 0x1b:
+		return s.escape()
+	}
+	if r < 0x20 || r == utf8.RuneError {
+		return Event{Key: KeyUnknown}, nil
+	}
+	return Event{Key: KeyRune, Rune: r}, nil
+}
+
+// escape 解析 ESC 开头的序列；后面没有数据时就是 Esc 键本身
+func (s *Screen) escape() (Event, error) {
+	if s.in.Buffered() == 0 {
+		return Event{Key: KeyEsc}, nil
+	}
+	b, err := s.in.ReadByte()
+	if err != nil {
+		return Event{}, err
+	}
+	if b != '[' && b != 'O' {
+		return Event{Key: KeyUnknown}, nil
+	}
+	var seq []byte
+	for {
+		c, err := s.in.ReadByte()
+		if err != nil {
+			return Event{}, err
+		}
+		seq = append(seq, c)
+		if c >= 0x40 && c <= 0x7e {
+			break
+		}
+	}
+	switch string(seq) {
+	case "A":
+		return Event{Key: KeyUp}, nil
+	case "B":
+		return Event{Key: KeyDown}, nil
+	case "C":
+		return Event{Key: KeyRight}, nil
+	case "D":
+		return Event{Key: KeyLeft}, nil
+	case "H", "1~", "7~":
+		return Event{Key: KeyHome}, nil
+	case "F", "4~", "8~":
+		return Event{Key: KeyEnd}, nil
+	case "5~":
+		return Event{Key: KeyPageUp}, nil
+	case "6~":
+		return Event{Key: KeyPageDown}, nil
+	}
+	return Event{Key: KeyUnknown}, nil
+}
+
+// Truncate 按显示宽度截断 (中文占两格)，ANSI 转义序列不算宽度
+func Truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	var sb strings.Builder
+	w := 0
+	cut := false
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			// 转义序列原样保留
+			j := i + 1
+			for j < len(s) && !(s[j] >= 0x40 && s[j] <= 0x7e && j > i+1) {
+				j++
+			}
+			if j < len(s) {
+				j++
+			}
+			sb.WriteString(s[i:j])
+			i = j
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		rw := runewidth.RuneWidth(r)
+		if w+rw > width {
+			cut = true
+			break
+		}
+		sb.WriteRune(r)
+		w += rw
+		i += size
+	}
+	if cut && strings.Contains(s, "\x1b[") {
+		sb.WriteString("\x1b[0m")
+	}
+	return sb.String()
+}
+
+// Pad 截断或用空格补齐到正好 width 个显示宽度
+func Pad(s string, width int) string {
+	s = Truncate(s, width)
+	if w := Width(s); w < width {
+		s += strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+// Width 显示宽度，不算 ANSI 转义序列
+func Width(s string) int {
+	w := 0
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			j := i + 1
+			for j < len(s) && !(s[j] >= 0x40 && s[j] <= 0x7e && j > i+1) {
+				j++
+			}
+			i = j + 1
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		w += runewidth.RuneWidth(r)
+		i += size
+	}
+	return w
+}